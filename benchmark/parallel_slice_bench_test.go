@@ -18,6 +18,17 @@ func BenchmarkParallelMap(b *testing.B) {
 	}
 }
 
+func BenchmarkParallelMapWithOptions(b *testing.B) {
+	for _, n := range lengths {
+		b.Run(fmt.Sprintf("ints_%d", n), func(b *testing.B) {
+			src := genSliceInt(n)
+			for i := 0; i < b.N; i++ {
+				_ = lop.MapWithOptions(src, func(x, _ int) int { return x * 2 }, lop.Options{})
+			}
+		})
+	}
+}
+
 func BenchmarkParallelForEach(b *testing.B) {
 	for _, n := range lengths {
 		b.Run(fmt.Sprintf("ints_%d", n), func(b *testing.B) {
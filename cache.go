@@ -0,0 +1,344 @@
+package lo
+
+import (
+	"sync"
+	"time"
+
+	"github.com/samber/lo/internal/xtime"
+)
+
+// CacheStats holds the counters of an LRUCache.
+type CacheStats struct {
+	Hits        int
+	Misses      int
+	Evictions   int
+	Expirations int
+}
+
+type lruEntry[K comparable, V any] struct {
+	key       K
+	value     V
+	expiresAt time.Time
+
+	// least recently used order
+	prev *lruEntry[K, V]
+	next *lruEntry[K, V]
+
+	// write order, which is also the expiration order since every entry shares the same TTL
+	prevWrite *lruEntry[K, V]
+	nextWrite *lruEntry[K, V]
+}
+
+// LRUCache is a size-bounded cache that evicts the least recently used entry when full.
+// Entries may also expire after a TTL, which is reset when the entry is written.
+// An LRUCache is safe for concurrent use.
+type LRUCache[K comparable, V any] struct {
+	mu sync.Mutex
+
+	size    int
+	ttl     time.Duration
+	onEvict []func(key K, value V)
+	now     func() time.Time
+
+	entries map[K]*lruEntry[K, V]
+
+	// head is the most recently used entry, tail the least recently used one
+	head *lruEntry[K, V]
+	tail *lruEntry[K, V]
+
+	// oldest is the least recently written entry, newest the most recently written one
+	oldest *lruEntry[K, V]
+	newest *lruEntry[K, V]
+
+	stats CacheStats
+}
+
+// NewLRUCache creates a cache holding at most `size` entries, each expiring `ttl` after it was written.
+// When size is lower than 1 the cache is unbounded, and when ttl is lower than or equal to 0 the entries
+// never expire. The optional callbacks are invoked, outside of the cache lock, for every entry
+// evicted because the cache is full or the entry expired.
+func NewLRUCache[K comparable, V any](size int, ttl time.Duration, onEvict ...func(key K, value V)) *LRUCache[K, V] {
+	return &LRUCache[K, V]{
+		size:    size,
+		ttl:     ttl,
+		onEvict: onEvict,
+		now:     xtime.Now,
+		entries: map[K]*lruEntry[K, V]{},
+	}
+}
+
+// Get returns the value of a key and whether it was found, and marks the key as recently used.
+func (c *LRUCache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+
+	evicted := c.deleteExpired()
+
+	// the value is copied under the lock, since Set updates the entries in place
+	var value V
+	e, ok := c.entries[key]
+	if ok {
+		c.stats.Hits++
+		c.moveToFront(e)
+		value = e.value
+	} else {
+		c.stats.Misses++
+	}
+
+	c.mu.Unlock()
+	c.notify(evicted)
+
+	return value, ok
+}
+
+// Peek returns the value of a key and whether it was found, without marking it as
+// recently used nor updating the stats.
+func (c *LRUCache[K, V]) Peek(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.entries[key]; ok && !c.isExpired(e) {
+		return e.value, true
+	}
+
+	var zero V
+	return zero, false
+}
+
+// Has returns true if the key is present and not expired. It does not mark the key as recently used.
+func (c *LRUCache[K, V]) Has(key K) bool {
+	_, ok := c.Peek(key)
+	return ok
+}
+
+// Set adds or updates a key, marks it as recently used and resets its TTL.
+// The least recently used entry is evicted when the cache is full.
+func (c *LRUCache[K, V]) Set(key K, value V) {
+	c.mu.Lock()
+
+	evicted := c.deleteExpired()
+
+	var expiresAt time.Time
+	if c.ttl > 0 {
+		expiresAt = c.now().Add(c.ttl)
+	}
+
+	if e, ok := c.entries[key]; ok {
+		e.value = value
+		e.expiresAt = expiresAt
+		c.moveToFront(e)
+		c.unlinkWrite(e)
+		c.pushWrite(e)
+	} else {
+		e := &lruEntry[K, V]{
+			key:       key,
+			value:     value,
+			expiresAt: expiresAt,
+		}
+
+		c.entries[key] = e
+		c.pushFront(e)
+		c.pushWrite(e)
+
+		if c.size > 0 && len(c.entries) > c.size {
+			lru := c.tail
+			c.remove(lru)
+			c.stats.Evictions++
+			evicted = append(evicted, lru)
+		}
+	}
+
+	c.mu.Unlock()
+	c.notify(evicted)
+}
+
+// Delete removes a key and returns true if it was present. Eviction callbacks are not invoked.
+func (c *LRUCache[K, V]) Delete(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		return false
+	}
+
+	c.remove(e)
+
+	return !c.isExpired(e)
+}
+
+// Len returns the number of entries that are not expired.
+func (c *LRUCache[K, V]) Len() int {
+	c.mu.Lock()
+	evicted := c.deleteExpired()
+	size := len(c.entries)
+	c.mu.Unlock()
+
+	c.notify(evicted)
+
+	return size
+}
+
+// Keys returns the keys that are not expired, from the most recently used to the least recently used.
+func (c *LRUCache[K, V]) Keys() []K {
+	c.mu.Lock()
+	evicted := c.deleteExpired()
+
+	result := make([]K, 0, len(c.entries))
+	for e := c.head; e != nil; e = e.next {
+		result = append(result, e.key)
+	}
+
+	c.mu.Unlock()
+	c.notify(evicted)
+
+	return result
+}
+
+// Purge removes every entry. Eviction callbacks are not invoked and stats are kept.
+func (c *LRUCache[K, V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = map[K]*lruEntry[K, V]{}
+	c.head, c.tail = nil, nil
+	c.oldest, c.newest = nil, nil
+}
+
+// Stats returns the hit, miss, eviction and expiration counters.
+func (c *LRUCache[K, V]) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.stats
+}
+
+func (c *LRUCache[K, V]) isExpired(e *lruEntry[K, V]) bool {
+	return c.ttl > 0 && !c.now().Before(e.expiresAt)
+}
+
+// deleteExpired removes the expired entries, starting from the least recently written one.
+func (c *LRUCache[K, V]) deleteExpired() []*lruEntry[K, V] {
+	if c.ttl <= 0 {
+		return nil
+	}
+
+	var evicted []*lruEntry[K, V]
+
+	for c.oldest != nil && c.isExpired(c.oldest) {
+		e := c.oldest
+		c.remove(e)
+		c.stats.Expirations++
+		evicted = append(evicted, e)
+	}
+
+	return evicted
+}
+
+func (c *LRUCache[K, V]) notify(evicted []*lruEntry[K, V]) {
+	for _, e := range evicted {
+		for _, callback := range c.onEvict {
+			callback(e.key, e.value)
+		}
+	}
+}
+
+func (c *LRUCache[K, V]) remove(e *lruEntry[K, V]) {
+	delete(c.entries, e.key)
+	c.unlink(e)
+	c.unlinkWrite(e)
+}
+
+func (c *LRUCache[K, V]) pushFront(e *lruEntry[K, V]) {
+	e.prev = nil
+	e.next = c.head
+
+	if c.head == nil {
+		c.tail = e
+	} else {
+		c.head.prev = e
+	}
+
+	c.head = e
+}
+
+func (c *LRUCache[K, V]) unlink(e *lruEntry[K, V]) {
+	if e.prev == nil {
+		c.head = e.next
+	} else {
+		e.prev.next = e.next
+	}
+
+	if e.next == nil {
+		c.tail = e.prev
+	} else {
+		e.next.prev = e.prev
+	}
+
+	e.prev, e.next = nil, nil
+}
+
+func (c *LRUCache[K, V]) moveToFront(e *lruEntry[K, V]) {
+	if c.head == e {
+		return
+	}
+
+	c.unlink(e)
+	c.pushFront(e)
+}
+
+func (c *LRUCache[K, V]) pushWrite(e *lruEntry[K, V]) {
+	e.prevWrite = c.newest
+	e.nextWrite = nil
+
+	if c.newest == nil {
+		c.oldest = e
+	} else {
+		c.newest.nextWrite = e
+	}
+
+	c.newest = e
+}
+
+func (c *LRUCache[K, V]) unlinkWrite(e *lruEntry[K, V]) {
+	if e.prevWrite == nil {
+		c.oldest = e.nextWrite
+	} else {
+		e.prevWrite.nextWrite = e.nextWrite
+	}
+
+	if e.nextWrite == nil {
+		c.newest = e.prevWrite
+	} else {
+		e.nextWrite.prevWrite = e.prevWrite
+	}
+
+	e.prevWrite, e.nextWrite = nil, nil
+}
+
+// Memoize returns a function caching the results of f, forever.
+// Concurrent calls for a key that is not cached yet may invoke f several times.
+func Memoize[K comparable, V any](f func(key K) V) func(key K) V {
+	return MemoizeWithCache(NewLRUCache[K, V](0, 0), f)
+}
+
+// MemoizeWithTTL returns a function caching the results of f for the given duration.
+// Concurrent calls for a key that is not cached yet may invoke f several times.
+func MemoizeWithTTL[K comparable, V any](ttl time.Duration, f func(key K) V) func(key K) V {
+	return MemoizeWithCache(NewLRUCache[K, V](0, ttl), f)
+}
+
+// MemoizeWithCache returns a function caching the results of f in the given cache,
+// which sets the size, TTL and eviction policy of the memoization.
+// Concurrent calls for a key that is not cached yet may invoke f several times.
+func MemoizeWithCache[K comparable, V any](cache *LRUCache[K, V], f func(key K) V) func(key K) V {
+	return func(key K) V {
+		if value, ok := cache.Get(key); ok {
+			return value
+		}
+
+		value := f(key)
+		cache.Set(key, value)
+
+		return value
+	}
+}
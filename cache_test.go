@@ -0,0 +1,284 @@
+package lo
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/samber/lo/internal/xtime"
+)
+
+func TestLRUCache(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	cache := NewLRUCache[string, int](2, 0)
+	cache.Set("a", 1)
+	cache.Set("b", 2)
+
+	value, ok := cache.Get("a")
+	is.True(ok)
+	is.Equal(1, value)
+
+	// "b" is the least recently used entry
+	cache.Set("c", 3)
+	is.False(cache.Has("b"))
+	is.True(cache.Has("a"))
+	is.True(cache.Has("c"))
+	is.Equal([]string{"c", "a"}, cache.Keys())
+	is.Equal(2, cache.Len())
+
+	value, ok = cache.Get("b")
+	is.False(ok)
+	is.Zero(value)
+
+	cache.Set("a", 10)
+	value, _ = cache.Peek("a")
+	is.Equal(10, value)
+
+	is.True(cache.Delete("a"))
+	is.False(cache.Delete("a"))
+	is.Equal([]string{"c"}, cache.Keys())
+
+	is.Equal(CacheStats{Hits: 1, Misses: 1, Evictions: 1}, cache.Stats())
+
+	cache.Purge()
+	is.Zero(cache.Len())
+	is.Empty(cache.Keys())
+}
+
+func TestLRUCachePeek(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	cache := NewLRUCache[string, int](2, 0)
+	cache.Set("a", 1)
+	cache.Set("b", 2)
+
+	// Peek does not mark "a" as recently used
+	value, ok := cache.Peek("a")
+	is.True(ok)
+	is.Equal(1, value)
+
+	cache.Set("c", 3)
+	is.False(cache.Has("a"))
+	is.Equal(CacheStats{Evictions: 1}, cache.Stats())
+}
+
+func TestLRUCacheUnbounded(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	cache := NewLRUCache[int, int](0, 0)
+	for i := 0; i < 1000; i++ {
+		cache.Set(i, i)
+	}
+
+	is.Equal(1000, cache.Len())
+	is.Zero(cache.Stats().Evictions)
+}
+
+func TestLRUCacheTTL(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	clock := xtime.NewFakeClock()
+
+	var evicted []string
+	cache := NewLRUCache(10, time.Minute, func(key string, value int) {
+		evicted = append(evicted, key)
+	})
+	cache.now = clock.Now
+
+	cache.Set("a", 1)
+	clock.Sleep(30 * time.Second)
+	cache.Set("b", 2)
+	clock.Sleep(20 * time.Second)
+
+	// reading does not reset the TTL, writing does
+	_, ok := cache.Get("a")
+	is.True(ok)
+	cache.Set("b", 3)
+
+	clock.Sleep(10 * time.Second)
+	is.False(cache.Has("a"))
+	is.True(cache.Has("b"))
+
+	_, ok = cache.Get("a")
+	is.False(ok)
+	is.Equal([]string{"a"}, evicted)
+	is.Equal(1, cache.Len())
+
+	clock.Sleep(time.Hour)
+	is.Zero(cache.Len())
+	is.Equal([]string{"a", "b"}, evicted)
+	is.Equal(CacheStats{Hits: 1, Misses: 1, Expirations: 2}, cache.Stats())
+}
+
+func TestLRUCacheEvictionCallback(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	type eviction struct {
+		key   int
+		value string
+	}
+
+	var evicted []eviction
+	cache := NewLRUCache(2, 0, func(key int, value string) {
+		evicted = append(evicted, eviction{key, value})
+	})
+
+	cache.Set(1, "a")
+	cache.Set(2, "b")
+	cache.Set(3, "c")
+	cache.Get(2)
+	cache.Set(4, "d")
+
+	// explicit removals do not invoke callbacks
+	cache.Delete(2)
+	cache.Purge()
+
+	is.Equal([]eviction{{1, "a"}, {3, "c"}}, evicted)
+}
+
+func TestLRUCacheCallbackReentrancy(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	var cache *LRUCache[int, int]
+	cache = NewLRUCache(1, 0, func(key, value int) {
+		// callbacks run outside of the lock
+		is.Equal(1, cache.Len())
+	})
+
+	cache.Set(1, 1)
+	cache.Set(2, 2)
+	is.Equal(1, cache.Stats().Evictions)
+}
+
+func TestLRUCacheConcurrency(t *testing.T) { //nolint:paralleltest
+	// t.Parallel()
+	is := assert.New(t)
+
+	cache := NewLRUCache[int, int](50, time.Minute)
+
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+
+			for i := 0; i < 1000; i++ {
+				cache.Set((i*w)%100, i)
+				cache.Get(i % 100)
+				cache.Has(i % 10)
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	is.LessOrEqual(cache.Len(), 50)
+
+	stats := cache.Stats()
+	is.Equal(8000, stats.Hits+stats.Misses)
+}
+
+func TestLRUCacheConcurrentGetSet(t *testing.T) { //nolint:paralleltest
+	// t.Parallel()
+	is := assert.New(t)
+
+	cache := NewLRUCache[string, int](10, 0)
+	cache.Set("key", 0)
+
+	var wg sync.WaitGroup
+	var found int32
+	for w := 0; w < 4; w++ {
+		wg.Add(2)
+
+		go func(w int) {
+			defer wg.Done()
+
+			for i := 0; i < 500; i++ {
+				cache.Set("key", w*1000+i)
+			}
+		}(w)
+
+		go func() {
+			defer wg.Done()
+
+			for i := 0; i < 500; i++ {
+				if _, ok := cache.Get("key"); ok {
+					atomic.AddInt32(&found, 1)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	is.EqualValues(2000, found)
+}
+
+func TestMemoize(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	calls := 0
+	square := Memoize(func(n int) int {
+		calls++
+		return n * n
+	})
+
+	is.Equal(4, square(2))
+	is.Equal(4, square(2))
+	is.Equal(9, square(3))
+	is.Equal(2, calls)
+}
+
+func TestMemoizeWithTTL(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	calls := 0
+	square := MemoizeWithTTL(time.Minute, func(n int) int {
+		calls++
+		return n * n
+	})
+
+	is.Equal(4, square(2))
+	is.Equal(4, square(2))
+	is.Equal(1, calls)
+}
+
+func TestMemoizeWithCache(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	clock := xtime.NewFakeClock()
+	cache := NewLRUCache[int, int](2, time.Minute)
+	cache.now = clock.Now
+
+	calls := 0
+	square := MemoizeWithCache(cache, func(n int) int {
+		calls++
+		return n * n
+	})
+
+	square(1)
+	square(2)
+	square(1)
+	is.Equal(2, calls)
+
+	// 2 is the least recently used key
+	square(3)
+	square(2)
+	is.Equal(4, calls)
+
+	clock.Sleep(time.Minute)
+	square(3)
+	is.Equal(5, calls)
+	is.Equal(CacheStats{Hits: 1, Misses: 5, Evictions: 2, Expirations: 2}, cache.Stats())
+}
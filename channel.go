@@ -2,6 +2,12 @@ package lo
 
 import (
 	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strconv"
 	"sync"
 	"time"
 
@@ -16,6 +22,13 @@ type DispatchingStrategy[T any] func(msg T, index uint64, channels []<-chan T) i
 // Underlying channels can have a fixed buffer capacity or be unbuffered when cap is 0.
 // Play: https://go.dev/play/p/UZGu2wVg3J2
 func ChannelDispatcher[T any](stream <-chan T, count, channelBufferCap int, strategy DispatchingStrategy[T]) []<-chan T {
+	return ChannelDispatcherWithContext(context.Background(), stream, count, channelBufferCap, strategy)
+}
+
+// ChannelDispatcherWithContext is like ChannelDispatcher, but stops reading the input channel when the
+// context is canceled, including while every child channel is full, and closes the child channels.
+// The messages already buffered in the child channels can still be read.
+func ChannelDispatcherWithContext[T any](ctx context.Context, stream <-chan T, count, channelBufferCap int, strategy DispatchingStrategy[T]) []<-chan T {
 	children := createChannels[T](count, channelBufferCap)
 
 	roChildren := channelsToReadOnly(children)
@@ -26,9 +39,32 @@ func ChannelDispatcher[T any](stream <-chan T, count, channelBufferCap int, stra
 
 		var i uint64
 
-		for msg := range stream {
+		for {
+			var msg T
+			var ok bool
+
+			select {
+			case msg, ok = <-stream:
+			case <-ctx.Done():
+				return
+			}
+
+			if !ok {
+				return
+			}
+
+			// without cancellation, the strategies and the send block like in ChannelDispatcher
+			if ctx.Done() != nil && !waitForRoom(ctx, roChildren) {
+				return
+			}
+
 			destination := strategy(msg, i, roChildren) % count
-			children[destination] <- msg
+
+			select {
+			case children[destination] <- msg:
+			case <-ctx.Done():
+				return
+			}
 
 			i++
 		}
@@ -37,6 +73,24 @@ func ChannelDispatcher[T any](stream <-chan T, count, channelBufferCap int, stra
 	return roChildren
 }
 
+// waitForRoom blocks until at least one channel is not full, so that the strategies looking for
+// a non-full channel return. It returns false if the context is canceled first.
+func waitForRoom[T any](ctx context.Context, channels []<-chan T) bool {
+	for {
+		if ctx.Err() != nil {
+			return false
+		}
+
+		for i := range channels {
+			if channelIsNotFull(channels[i]) {
+				return true
+			}
+		}
+
+		time.Sleep(10 * time.Microsecond) // prevent CPU from burning 🔥
+	}
+}
+
 func createChannels[T any](count, channelBufferCap int) []chan T {
 	children := make([]chan T, 0, count)
 
@@ -156,6 +210,110 @@ func DispatchingStrategyMost[T any](msg T, index uint64, channels []<-chan T) in
 	return i
 }
 
+// DispatchingStrategyByKey distributes the messages with the same key to the same channel, keeping
+// their order. If the channel capacity is exceeded, the dispatcher waits for it to have room.
+func DispatchingStrategyByKey[T any, K comparable](key func(msg T) K) DispatchingStrategy[T] {
+	return func(msg T, index uint64, channels []<-chan T) int {
+		return int(hashKey(key(msg)) % uint64(len(channels)))
+	}
+}
+
+// DispatchingStrategyConsistentHash distributes the messages with the same key to the same channel,
+// keeping their order, like DispatchingStrategyByKey. Channels are placed on a hash ring with `replicas`
+// virtual nodes each (128 when lower than 1), so that adding or removing the last channel only moves
+// the keys of this channel. If the channel capacity is exceeded, the dispatcher waits for it to have room.
+func DispatchingStrategyConsistentHash[T any, K comparable](key func(msg T) K, replicas int) DispatchingStrategy[T] {
+	if replicas < 1 {
+		replicas = 128
+	}
+
+	var mu sync.Mutex
+	rings := map[int]*hashRing{}
+
+	return func(msg T, index uint64, channels []<-chan T) int {
+		mu.Lock()
+		ring, ok := rings[len(channels)]
+		if !ok {
+			ring = newHashRing(len(channels), replicas)
+			rings[len(channels)] = ring
+		}
+		mu.Unlock()
+
+		return ring.lookup(hashKey(key(msg)))
+	}
+}
+
+type hashRing struct {
+	hashes []uint64
+	nodes  []int
+}
+
+func newHashRing(count, replicas int) *hashRing {
+	points := make([]Tuple2[uint64, int], 0, count*replicas)
+
+	for node := 0; node < count; node++ {
+		for replica := 0; replica < replicas; replica++ {
+			points = append(points, T2(hashKey(strconv.Itoa(node)+"#"+strconv.Itoa(replica)), node))
+		}
+	}
+
+	sort.Slice(points, func(i, j int) bool {
+		return points[i].A < points[j].A
+	})
+
+	ring := &hashRing{
+		hashes: make([]uint64, len(points)),
+		nodes:  make([]int, len(points)),
+	}
+
+	for i := range points {
+		ring.hashes[i], ring.nodes[i] = points[i].Unpack()
+	}
+
+	return ring
+}
+
+// lookup returns the node of the first virtual node following the hash on the ring.
+func (r *hashRing) lookup(hash uint64) int {
+	i := sort.Search(len(r.hashes), func(i int) bool {
+		return r.hashes[i] >= hash
+	})
+
+	if i == len(r.hashes) {
+		i = 0
+	}
+
+	return r.nodes[i]
+}
+
+// hashKey returns a hash of the key, stable across processes.
+func hashKey[K comparable](key K) uint64 {
+	h := fnv.New64a()
+
+	switch k := any(key).(type) {
+	case string:
+		_, _ = h.Write([]byte(k))
+	case int:
+		_ = binary.Write(h, binary.LittleEndian, int64(k))
+	case int64:
+		_ = binary.Write(h, binary.LittleEndian, k)
+	case uint64:
+		_ = binary.Write(h, binary.LittleEndian, k)
+	default:
+		_, _ = fmt.Fprintf(h, "%#v", k)
+	}
+
+	// fnv does not spread short inputs well: finalize with the splitmix64 mixer.
+	x := h.Sum64()
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+
+	return x
+}
+
 // SliceToChannel returns a read-only channel of collection elements.
 // Play: https://go.dev/play/p/lIbSY3QmiEg
 func SliceToChannel[T any](bufferSize int, collection []T) <-chan T {
@@ -254,10 +412,104 @@ func BufferWithTimeout[T any](ch <-chan T, size int, timeout time.Duration) (col
 	return BufferWithContext(ctx, ch, size)
 }
 
+// Batch groups the messages of a channel into batches of at most maxSize messages. A batch is sent
+// when it is full, when maxWait elapsed since its first message, and when the channel is closed.
+// The downstream channel is closed after the last batch. A maxWait lower than or equal to 0 disables
+// the time limit.
+func Batch[T any](ch <-chan T, maxSize int, maxWait time.Duration) <-chan []T {
+	return BatchWithContext(context.Background(), ch, maxSize, maxWait)
+}
+
+// BatchWithContext is like Batch, but stops when the context is canceled: the pending batch is
+// dropped and the downstream channel is closed.
+func BatchWithContext[T any](ctx context.Context, ch <-chan T, maxSize int, maxWait time.Duration) <-chan []T {
+	if maxSize <= 0 {
+		panic("lo.Batch: maxSize must be greater than 0")
+	}
+
+	out := make(chan []T)
+
+	go func() {
+		defer close(out)
+
+		var batch []T
+		var timer *time.Timer
+		var timeout <-chan time.Time
+
+		stopTimer := func() {
+			if timer != nil {
+				timer.Stop()
+				timer, timeout = nil, nil
+			}
+		}
+		defer stopTimer()
+
+		flush := func() bool {
+			stopTimer()
+
+			if len(batch) == 0 {
+				return true
+			}
+
+			select {
+			case out <- batch:
+				batch = nil
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		for {
+			select {
+			case item, ok := <-ch:
+				if !ok {
+					flush()
+					return
+				}
+
+				if batch == nil {
+					batch = make([]T, 0, maxSize)
+
+					if maxWait > 0 {
+						timer = time.NewTimer(maxWait)
+						timeout = timer.C
+					}
+				}
+
+				batch = append(batch, item)
+
+				if len(batch) >= maxSize && !flush() {
+					return
+				}
+
+			case <-timeout:
+				timer, timeout = nil, nil
+
+				if !flush() {
+					return
+				}
+
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out
+}
+
 // FanIn collects messages from multiple input channels into a single buffered channel.
 // Output messages have no priority. When all upstream channels reach EOF, downstream channel closes.
 // Play: https://go.dev/play/p/FH8Wq-T04Jb
 func FanIn[T any](channelBufferCap int, upstreams ...<-chan T) <-chan T {
+	return FanInWithContext(context.Background(), channelBufferCap, upstreams...)
+}
+
+// FanInWithContext is like FanIn, but stops reading the upstream channels when the context is canceled,
+// even if the downstream channel is not consumed anymore, and closes the downstream channel.
+// The messages already buffered in the downstream channel can still be read.
+func FanInWithContext[T any](ctx context.Context, channelBufferCap int, upstreams ...<-chan T) <-chan T {
 	out := make(chan T, channelBufferCap)
 	var wg sync.WaitGroup
 
@@ -265,10 +517,25 @@ func FanIn[T any](channelBufferCap int, upstreams ...<-chan T) <-chan T {
 	wg.Add(len(upstreams))
 	for i := range upstreams {
 		go func(index int) {
-			for n := range upstreams[index] {
-				out <- n
+			defer wg.Done()
+
+			for {
+				select {
+				case n, ok := <-upstreams[index]:
+					if !ok {
+						return
+					}
+
+					select {
+					case out <- n:
+					case <-ctx.Done():
+						return
+					}
+
+				case <-ctx.Done():
+					return
+				}
 			}
-			wg.Done()
 		}(i)
 	}
 
@@ -285,20 +552,554 @@ func FanIn[T any](channelBufferCap int, upstreams ...<-chan T) <-chan T {
 // channels is full, broadcasting is paused.
 // Play: https://go.dev/play/p/2LHxcjKX23L
 func FanOut[T any](count, channelsBufferCap int, upstream <-chan T) []<-chan T {
+	return FanOutWithContext(context.Background(), count, channelsBufferCap, upstream, FanOutOptions{})
+}
+
+// FanOutOptions configures FanOutWithContext.
+type FanOutOptions struct {
+	// SkipSlowSubscribers drops the message for the downstream channels that are full, instead of
+	// pausing the broadcast until they have room. An unbuffered downstream channel only receives the
+	// messages sent while it is being read.
+	SkipSlowSubscribers bool
+}
+
+// FanOutWithContext is like FanOut, but stops reading the upstream channel when the context is canceled,
+// even if a downstream channel is not consumed anymore, and closes the downstream channels. The messages
+// already buffered in the downstream channels can still be read.
+func FanOutWithContext[T any](ctx context.Context, count, channelsBufferCap int, upstream <-chan T, options FanOutOptions) []<-chan T {
 	downstreams := createChannels[T](count, channelsBufferCap)
 
 	go func() {
-		for msg := range upstream {
-			for i := range downstreams {
-				downstreams[i] <- msg
+		// Close out once all the output goroutines are done.
+		defer closeChannels(downstreams)
+
+		for {
+			select {
+			case msg, ok := <-upstream:
+				if !ok || !fanOutSend(ctx, downstreams, msg, options.SkipSlowSubscribers) {
+					return
+				}
+
+			case <-ctx.Done():
+				return
 			}
 		}
+	}()
 
-		// Close out once all the output goroutines are done.
-		for i := range downstreams {
-			close(downstreams[i])
+	return channelsToReadOnly(downstreams)
+}
+
+// fanOutSend broadcasts a message, and returns false if the context was canceled first.
+func fanOutSend[T any](ctx context.Context, downstreams []chan T, msg T, skipSlow bool) bool {
+	for i := range downstreams {
+		if skipSlow {
+			select {
+			case downstreams[i] <- msg:
+			default:
+			}
+
+			continue
+		}
+
+		select {
+		case downstreams[i] <- msg:
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	return ctx.Err() == nil
+}
+
+// OverflowPolicy is the behavior of a Broadcaster when the channel of a subscriber is full.
+type OverflowPolicy int
+
+const (
+	// OverflowBlock waits until the subscriber has room for the message, pausing the broadcast.
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDropOldest discards the oldest message buffered for the subscriber to make room.
+	OverflowDropOldest
+	// OverflowDropNewest discards the message for the subscriber.
+	OverflowDropNewest
+)
+
+// ErrBroadcasterClosed is returned by Broadcaster.Publish once the broadcaster is closed.
+var ErrBroadcasterClosed = errors.New("lo.Broadcaster: broadcaster is closed")
+
+// BroadcasterOptions configures a Broadcaster.
+type BroadcasterOptions struct {
+	// Replay is the number of last published messages sent to the new subscribers. Defaults to 0.
+	Replay int
+}
+
+// SubscribeOptions configures a subscription to a Broadcaster.
+type SubscribeOptions struct {
+	// BufferSize is the capacity of the subscriber channel. With the drop policies, an unbuffered
+	// subscriber only receives the messages published while it is being read.
+	BufferSize int
+	// Overflow is the behavior when the subscriber channel is full. Defaults to OverflowBlock.
+	Overflow OverflowPolicy
+}
+
+// Broadcaster sends every published message to its current subscribers. Subscribers can join and
+// leave at any time, and each one has its own buffer and overflow policy. A full subscriber with the
+// OverflowBlock policy pauses the publications, but not the subscriptions.
+type Broadcaster[T any] struct {
+	replay int
+
+	publishing sync.Mutex // serializes the publications, so that every subscriber receives the same order
+
+	mu          sync.Mutex
+	subscribers []*broadcasterSubscriber[T]
+	history     []T
+	closed      bool
+	done        chan struct{}
+	once        sync.Once
+}
+
+type broadcasterSubscriber[T any] struct {
+	ch       chan T
+	overflow OverflowPolicy
+	done     chan struct{}
+	once     sync.Once
+
+	mu     sync.Mutex // held while sending, so that the channel is not closed during a send
+	closed bool
+}
+
+// NewBroadcaster creates a Broadcaster without subscribers.
+func NewBroadcaster[T any](options BroadcasterOptions) *Broadcaster[T] {
+	replay := options.Replay
+	if replay < 0 {
+		replay = 0
+	}
+
+	return &Broadcaster[T]{
+		replay: replay,
+		done:   make(chan struct{}),
+	}
+}
+
+// Subscribe returns a channel receiving the replayed messages, then the messages published from now on,
+// and a function closing the channel once called. Replayed messages that do not fit in the buffer of
+// the channel are skipped, oldest first. Once the broadcaster is closed, the channel is already closed.
+func (b *Broadcaster[T]) Subscribe(options SubscribeOptions) (<-chan T, func()) {
+	bufferSize := options.BufferSize
+	if bufferSize < 0 {
+		bufferSize = 0
+	}
+
+	sub := &broadcasterSubscriber[T]{
+		ch:       make(chan T, bufferSize),
+		overflow: options.Overflow,
+		done:     make(chan struct{}),
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		close(sub.ch)
+		return sub.ch, func() {}
+	}
+
+	history := b.history
+	if len(history) > bufferSize {
+		history = history[len(history)-bufferSize:]
+	}
+
+	for i := range history {
+		sub.ch <- history[i]
+	}
+
+	b.subscribers = append(b.subscribers, sub)
+
+	return sub.ch, func() {
+		b.unsubscribe(sub)
+	}
+}
+
+func (b *Broadcaster[T]) unsubscribe(sub *broadcasterSubscriber[T]) {
+	b.mu.Lock()
+	for i := range b.subscribers {
+		if b.subscribers[i] == sub {
+			// a publication may be iterating over the current slice: it is not updated in place
+			b.subscribers = append(append([]*broadcasterSubscriber[T]{}, b.subscribers[:i]...), b.subscribers[i+1:]...)
+			break
+		}
+	}
+	b.mu.Unlock()
+
+	sub.close()
+}
+
+// Subscribers returns the number of current subscribers.
+func (b *Broadcaster[T]) Subscribers() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return len(b.subscribers)
+}
+
+// Publish sends a message to every current subscriber, in subscription order, and returns
+// ErrBroadcasterClosed once the broadcaster is closed.
+func (b *Broadcaster[T]) Publish(msg T) error {
+	return b.PublishWithContext(context.Background(), msg)
+}
+
+// PublishWithContext is like Publish, but stops waiting for the full subscribers with the OverflowBlock
+// policy when the context is canceled. The remaining subscribers do not receive the message, and
+// ctx.Err() is returned.
+func (b *Broadcaster[T]) PublishWithContext(ctx context.Context, msg T) error {
+	b.publishing.Lock()
+	defer b.publishing.Unlock()
+
+	b.mu.Lock()
+
+	if b.closed {
+		b.mu.Unlock()
+		return ErrBroadcasterClosed
+	}
+
+	if b.replay > 0 {
+		if len(b.history) == b.replay {
+			b.history = append(b.history[:0], b.history[1:]...)
+		}
+
+		b.history = append(b.history, msg)
+	}
+
+	// the sends may block: they happen outside of the lock, so that Subscribe does not wait for them
+	subscribers := b.subscribers
+
+	b.mu.Unlock()
+
+	for i := range subscribers {
+		if err := subscribers[i].send(ctx, b.done, msg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Close closes the channels of every subscriber, and unblocks the pending publications.
+// Calling Close more than once is a no-op.
+func (b *Broadcaster[T]) Close() {
+	// wake up a publication blocked on a subscriber
+	b.once.Do(func() {
+		close(b.done)
+	})
+
+	b.mu.Lock()
+	subscribers := b.subscribers
+	b.closed = true
+	b.subscribers = nil
+	b.history = nil
+	b.mu.Unlock()
+
+	for i := range subscribers {
+		subscribers[i].close()
+	}
+}
+
+// close closes the channel of the subscriber, once the pending send returned.
+func (s *broadcasterSubscriber[T]) close() {
+	// wake up a publication blocked on this subscriber, before waiting for the lock it holds
+	s.once.Do(func() {
+		close(s.done)
+	})
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.closed {
+		s.closed = true
+		close(s.ch)
+	}
+}
+
+func (s *broadcasterSubscriber[T]) send(ctx context.Context, closing <-chan struct{}, msg T) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil
+	}
+
+	switch s.overflow {
+	case OverflowDropNewest:
+		select {
+		case s.ch <- msg:
+		default:
+		}
+
+	case OverflowDropOldest:
+		for {
+			select {
+			case s.ch <- msg:
+				return nil
+			default:
+			}
+
+			if cap(s.ch) == 0 {
+				return nil
+			}
+
+			// the subscriber may read concurrently
+			select {
+			case <-s.ch:
+			default:
+			}
+		}
+
+	default:
+		select {
+		case s.ch <- msg:
+		case <-s.done:
+		case <-closing:
+			return ErrBroadcasterClosed
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
+
+// ChanOptions configures the channel stages ChanMap, ChanFilter, ChanFlatMap and ChanForEach.
+type ChanOptions struct {
+	// Workers is the number of messages processed at the same time. Defaults to 1.
+	Workers int
+	// BufferSize is the capacity of the downstream channel.
+	BufferSize int
+	// PreserveOrder keeps the downstream messages in the order of the upstream messages,
+	// even when several workers are used.
+	PreserveOrder bool
+}
+
+func (o ChanOptions) workers() int {
+	if o.Workers < 1 {
+		return 1
+	}
+
+	return o.Workers
+}
+
+// ChanMap transforms the messages of a channel. The downstream channel is closed once the upstream
+// channel is closed and every message is processed, or as soon as the context is canceled.
+func ChanMap[T, R any](ctx context.Context, upstream <-chan T, transform func(item T) R, options ChanOptions) <-chan R {
+	return chanStage(ctx, upstream, options, func(item T) []R {
+		return []R{transform(item)}
+	})
+}
+
+// ChanFilter keeps the messages of a channel for which the predicate returns true. The downstream
+// channel is closed once the upstream channel is closed and every message is processed, or as soon
+// as the context is canceled.
+func ChanFilter[T any](ctx context.Context, upstream <-chan T, predicate func(item T) bool, options ChanOptions) <-chan T {
+	return chanStage(ctx, upstream, options, func(item T) []T {
+		if predicate(item) {
+			return []T{item}
+		}
+
+		return nil
+	})
+}
+
+// ChanFlatMap transforms each message of a channel into zero or more messages. The downstream channel
+// is closed once the upstream channel is closed and every message is processed, or as soon as the
+// context is canceled.
+func ChanFlatMap[T, R any](ctx context.Context, upstream <-chan T, transform func(item T) []R, options ChanOptions) <-chan R {
+	return chanStage(ctx, upstream, options, transform)
+}
+
+// ChanForEach invokes the callback for each message of a channel, and blocks until the upstream channel
+// is closed and every message is processed. When the context is canceled, it stops reading the upstream
+// channel, waits for the running callbacks and returns ctx.Err(). PreserveOrder is ignored.
+func ChanForEach[T any](ctx context.Context, upstream <-chan T, callback func(item T), options ChanOptions) error {
+	var wg sync.WaitGroup
+
+	workers := options.workers()
+	wg.Add(workers)
+
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+
+			for {
+				select {
+				case item, ok := <-upstream:
+					if !ok {
+						return
+					}
+
+					callback(item)
+
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	return ctx.Err()
+}
+
+func chanStage[T, R any](ctx context.Context, upstream <-chan T, options ChanOptions, process func(item T) []R) <-chan R {
+	out := make(chan R, options.BufferSize)
+
+	if options.PreserveOrder && options.workers() > 1 {
+		go chanStageOrdered(ctx, upstream, out, options.workers(), process)
+	} else {
+		go chanStageUnordered(ctx, upstream, out, options.workers(), process)
+	}
+
+	return out
+}
+
+// chanSend sends the messages downstream, and returns false if the context was canceled first.
+func chanSend[R any](ctx context.Context, out chan<- R, items []R) bool {
+	for i := range items {
+		select {
+		case out <- items[i]:
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	return true
+}
+
+func chanStageUnordered[T, R any](ctx context.Context, upstream <-chan T, out chan<- R, workers int, process func(item T) []R) {
+	defer close(out)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+
+			for {
+				select {
+				case item, ok := <-upstream:
+					if !ok || !chanSend(ctx, out, process(item)) {
+						return
+					}
+
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+type chanOrderedJob[T, R any] struct {
+	item   T
+	result chan []R
+}
+
+// chanStageOrdered dispatches the messages to the workers, and forwards their results in the upstream order:
+// each message gets a result slot, queued in order and filled by the worker.
+func chanStageOrdered[T, R any](ctx context.Context, upstream <-chan T, out chan<- R, workers int, process func(item T) []R) {
+	jobs := make(chan chanOrderedJob[T, R])
+	slots := make(chan chan []R, workers)
+
+	var wg sync.WaitGroup
+	wg.Add(workers + 1)
+
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+
+			for job := range jobs {
+				job.result <- process(job.item)
+			}
+		}()
+	}
+
+	// collector
+	go func() {
+		defer wg.Done()
+		defer close(out)
+
+		for slot := range slots {
+			select {
+			case items := <-slot:
+				if !chanSend(ctx, out, items) {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
 		}
 	}()
 
-	return channelsToReadOnly(downstreams)
+	// dispatcher
+	defer wg.Wait()
+	defer close(jobs)
+	defer close(slots)
+
+	for {
+		select {
+		case item, ok := <-upstream:
+			if !ok {
+				return
+			}
+
+			job := chanOrderedJob[T, R]{item: item, result: make(chan []R, 1)}
+
+			select {
+			case slots <- job.result:
+			case <-ctx.Done():
+				return
+			}
+
+			select {
+			case jobs <- job:
+			case <-ctx.Done():
+				return
+			}
+
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// ChanTimeWindow groups the messages of a channel into the time windows of the aggregator, and sends
+// each window once closed. When the upstream channel is closed, the open windows are flushed and the
+// downstream channel is closed. When the context is canceled, the open windows are dropped and the
+// downstream channel is closed. The aggregator must not be used elsewhere.
+func ChanTimeWindow[T, R any](ctx context.Context, upstream <-chan T, windows *TimeWindows[T, R]) <-chan TimeWindow[R] {
+	out := make(chan TimeWindow[R])
+
+	go func() {
+		defer close(out)
+
+		for {
+			select {
+			case item, ok := <-upstream:
+				if !ok {
+					chanSend(ctx, out, windows.Flush())
+					return
+				}
+
+				if !chanSend(ctx, out, windows.Add(item)) {
+					return
+				}
+
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out
 }
@@ -2,6 +2,9 @@ package lo
 
 import (
 	"context"
+	"runtime"
+	"strconv"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -96,6 +99,36 @@ func TestChannelDispatcher(t *testing.T) {
 	is.Zero(cap(children[0]))
 }
 
+func TestChannelDispatcherWithContext(t *testing.T) { //nolint:paralleltest
+	// t.Parallel()
+	testWithTimeout(t, 100*time.Millisecond)
+	is := assert.New(t)
+
+	ch := make(chan int, 10)
+	ch <- 0
+	ch <- 1
+	ch <- 2
+	ch <- 3
+
+	ctx, cancel := context.WithCancel(context.Background())
+	children := ChannelDispatcherWithContext(ctx, ch, 2, 1, DispatchingStrategyRoundRobin[int])
+
+	// every child channel is full and nobody reads them
+	for len(children[0]) != 1 || len(children[1]) != 1 {
+		runtime.Gosched()
+	}
+
+	cancel()
+
+	is.Equal([]int{0}, ChannelToSlice(children[0]))
+	is.Equal([]int{1}, ChannelToSlice(children[1]))
+
+	// the dispatcher stops when the stream is closed
+	ch2 := SliceToChannel(0, []int{0, 1, 2, 3})
+	children = ChannelDispatcherWithContext(context.Background(), ch2, 2, 0, DispatchingStrategyFirst[int])
+	is.Equal([]int{0, 1, 2, 3}, ChannelToSlice(FanIn(0, children...)))
+}
+
 func TestDispatchingStrategyRoundRobin(t *testing.T) {
 	t.Parallel()
 	testWithTimeout(t, 100*time.Millisecond)
@@ -215,6 +248,85 @@ func TestDispatchingStrategyMost(t *testing.T) {
 	is.Zero(DispatchingStrategyMost(42, 0, rochildren))
 }
 
+func TestDispatchingStrategyByKey(t *testing.T) { //nolint:paralleltest
+	// t.Parallel()
+	testWithTimeout(t, 100*time.Millisecond)
+	is := assert.New(t)
+
+	type event struct {
+		userID string
+		seq    int
+	}
+
+	children := createChannels[event](3, 2)
+	rochildren := channelsToReadOnly(children)
+	defer closeChannels(children)
+
+	strategy := DispatchingStrategyByKey(func(e event) string {
+		return e.userID
+	})
+
+	// the same key is always routed to the same channel, even when it is full
+	first := strategy(event{userID: "alice", seq: 0}, 0, rochildren)
+	children[first] <- event{}
+	children[first] <- event{}
+	for i := 1; i < 10; i++ {
+		is.Equal(first, strategy(event{userID: "alice", seq: i}, uint64(i), rochildren))
+	}
+
+	// keys are spread over the channels
+	seen := map[int]bool{}
+	for i := 0; i < 100; i++ {
+		destination := strategy(event{userID: strconv.Itoa(i)}, uint64(i), rochildren)
+		is.True(destination >= 0 && destination < 3)
+		seen[destination] = true
+	}
+	is.Len(seen, 3)
+
+	// composite keys
+	type key struct {
+		tenant string
+		user   int
+	}
+	byComposite := DispatchingStrategyByKey(func(e event) key {
+		return key{tenant: "acme", user: e.seq}
+	})
+	is.Equal(byComposite(event{seq: 42}, 0, rochildren), byComposite(event{userID: "x", seq: 42}, 1, rochildren))
+}
+
+func TestDispatchingStrategyConsistentHash(t *testing.T) { //nolint:paralleltest
+	// t.Parallel()
+	testWithTimeout(t, 100*time.Millisecond)
+	is := assert.New(t)
+
+	strategy := DispatchingStrategyConsistentHash(func(msg int) int {
+		return msg
+	}, 0)
+
+	four := channelsToReadOnly(createChannels[int](4, 0))
+	five := channelsToReadOnly(createChannels[int](5, 0))
+
+	counts := map[int]int{}
+	moved := 0
+
+	for key := 0; key < 1000; key++ {
+		before := strategy(key, 0, four)
+		is.Equal(before, strategy(key, 1, four))
+		counts[before]++
+
+		after := strategy(key, 0, five)
+		if after != before {
+			// only the keys of the new channel move
+			is.Equal(4, after)
+			moved++
+		}
+	}
+
+	is.Len(counts, 4)
+	is.Positive(moved)
+	is.Less(moved, 400)
+}
+
 func TestSliceToChannel(t *testing.T) {
 	t.Parallel()
 	testWithTimeout(t, 100*time.Millisecond)
@@ -388,6 +500,211 @@ func TestBufferWithTimeout(t *testing.T) { //nolint:paralleltest
 	is.False(ok6)
 }
 
+func TestBatch(t *testing.T) { //nolint:paralleltest
+	// t.Parallel()
+	testWithTimeout(t, time.Second)
+	is := assert.New(t)
+
+	// flushes on size and on close
+	is.Equal([][]int{{1, 2}, {3, 4}, {5}}, ChannelToSlice(Batch(SliceToChannel(0, []int{1, 2, 3, 4, 5}), 2, time.Hour)))
+	is.Equal([][]int{{1, 2, 3}}, ChannelToSlice(Batch(SliceToChannel(0, []int{1, 2, 3}), 10, 0)))
+
+	// no empty batch
+	ch := make(chan int)
+	close(ch)
+	is.Empty(ChannelToSlice(Batch(ch, 2, time.Hour)))
+
+	is.PanicsWithValue("lo.Batch: maxSize must be greater than 0", func() {
+		Batch(ch, 0, time.Hour)
+	})
+}
+
+func TestBatchMaxWait(t *testing.T) { //nolint:paralleltest
+	// t.Parallel()
+	testWithTimeout(t, time.Second)
+	is := assert.New(t)
+
+	ch := make(chan int)
+	batches := Batch(ch, 10, 20*time.Millisecond)
+
+	start := time.Now()
+	ch <- 1
+	ch <- 2
+	ch <- 3
+
+	is.Equal([]int{1, 2, 3}, <-batches)
+	is.GreaterOrEqual(time.Since(start), 20*time.Millisecond)
+
+	// the timer starts with the first message of a batch
+	time.Sleep(30 * time.Millisecond)
+	ch <- 4
+	close(ch)
+
+	is.Equal([]int{4}, <-batches)
+	_, ok := <-batches
+	is.False(ok)
+}
+
+func TestBatchWithContext(t *testing.T) { //nolint:paralleltest
+	// t.Parallel()
+	testWithTimeout(t, time.Second)
+	is := assert.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	ch := make(chan int)
+	batches := BatchWithContext(ctx, ch, 2, 0)
+
+	ch <- 1
+	ch <- 2
+	is.Equal([]int{1, 2}, <-batches)
+	ch <- 3
+
+	cancel()
+
+	// the pending batch is dropped
+	_, ok := <-batches
+	is.False(ok)
+
+	// canceled while waiting for the batch to be received
+	ctx, cancel = context.WithCancel(context.Background())
+	batches = BatchWithContext(ctx, SliceToChannel(2, []int{1, 2}), 2, 0)
+	cancel()
+
+	is.LessOrEqual(len(ChannelToSlice(batches)), 1)
+}
+
+func TestChanMap(t *testing.T) { //nolint:paralleltest
+	// t.Parallel()
+	testWithTimeout(t, time.Second)
+	is := assert.New(t)
+
+	double := func(item int) int {
+		return item * 2
+	}
+
+	input := Range(100)
+	expected := Map(input, func(item, _ int) int { return item * 2 })
+
+	// a single worker keeps the order
+	is.Equal(expected, ChannelToSlice(ChanMap(context.Background(), SliceToChannel(0, input), double, ChanOptions{})))
+
+	// several workers
+	result := ChannelToSlice(ChanMap(context.Background(), SliceToChannel(0, input), double, ChanOptions{Workers: 4, BufferSize: 10}))
+	is.ElementsMatch(expected, result)
+
+	// several workers, order preserved
+	slow := func(item int) int {
+		if item%10 == 0 {
+			runtime.Gosched()
+		}
+		return item * 2
+	}
+	result = ChannelToSlice(ChanMap(context.Background(), SliceToChannel(0, input), slow, ChanOptions{Workers: 4, PreserveOrder: true}))
+	is.Equal(expected, result)
+}
+
+func TestChanFilter(t *testing.T) { //nolint:paralleltest
+	// t.Parallel()
+	testWithTimeout(t, time.Second)
+	is := assert.New(t)
+
+	even := func(item int) bool {
+		return item%2 == 0
+	}
+
+	is.Equal([]int{0, 2, 4, 6, 8}, ChannelToSlice(ChanFilter(context.Background(), SliceToChannel(0, Range(10)), even, ChanOptions{})))
+	is.Equal([]int{0, 2, 4, 6, 8}, ChannelToSlice(ChanFilter(context.Background(), SliceToChannel(0, Range(10)), even, ChanOptions{Workers: 3, PreserveOrder: true})))
+	is.ElementsMatch([]int{0, 2, 4, 6, 8}, ChannelToSlice(ChanFilter(context.Background(), SliceToChannel(0, Range(10)), even, ChanOptions{Workers: 3})))
+}
+
+func TestChanFlatMap(t *testing.T) { //nolint:paralleltest
+	// t.Parallel()
+	testWithTimeout(t, time.Second)
+	is := assert.New(t)
+
+	repeat := func(item int) []int {
+		return Times(item, func(int) int { return item })
+	}
+
+	is.Equal([]int{1, 2, 2, 3, 3, 3}, ChannelToSlice(ChanFlatMap(context.Background(), SliceToChannel(0, []int{0, 1, 2, 3}), repeat, ChanOptions{})))
+	is.Equal([]int{1, 2, 2, 3, 3, 3}, ChannelToSlice(ChanFlatMap(context.Background(), SliceToChannel(0, []int{0, 1, 2, 3}), repeat, ChanOptions{Workers: 2, PreserveOrder: true})))
+	is.ElementsMatch([]int{1, 2, 2, 3, 3, 3}, ChannelToSlice(ChanFlatMap(context.Background(), SliceToChannel(0, []int{0, 1, 2, 3}), repeat, ChanOptions{Workers: 2})))
+}
+
+func TestChanForEach(t *testing.T) { //nolint:paralleltest
+	// t.Parallel()
+	testWithTimeout(t, time.Second)
+	is := assert.New(t)
+
+	var sum int64
+	err := ChanForEach(context.Background(), SliceToChannel(0, Range(101)), func(item int) {
+		atomic.AddInt64(&sum, int64(item))
+	}, ChanOptions{Workers: 4})
+
+	is.NoError(err)
+	is.Equal(int64(5050), sum)
+
+	// canceled while the upstream channel is still open
+	ctx, cancel := context.WithCancel(context.Background())
+	upstream := make(chan int)
+	count := 0
+
+	go func() {
+		upstream <- 1
+		upstream <- 2
+	}()
+
+	err = ChanForEach(ctx, upstream, func(item int) {
+		count++
+		if item == 2 {
+			cancel()
+		}
+	}, ChanOptions{})
+
+	is.ErrorIs(err, context.Canceled)
+	is.Equal(2, count)
+}
+
+func TestChanStagesCancellation(t *testing.T) { //nolint:paralleltest
+	// t.Parallel()
+	testWithTimeout(t, time.Second)
+	is := assert.New(t)
+
+	identity := func(item int) int {
+		return item
+	}
+
+	for _, options := range []ChanOptions{{}, {Workers: 4}, {Workers: 4, PreserveOrder: true}} {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		// the upstream never closes and the consumer stops reading
+		upstream := make(chan int)
+		go func() {
+			for i := 0; ; i++ {
+				select {
+				case upstream <- i:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+
+		downstream := ChanMap(ctx, ChanFilter(ctx, upstream, func(int) bool { return true }, options), identity, options)
+
+		<-downstream
+		<-downstream
+		cancel()
+
+		// the downstream channel is closed
+		for range downstream { //nolint:revive
+		}
+
+		_, ok := <-downstream
+		is.False(ok)
+	}
+}
+
 func TestFanIn(t *testing.T) { //nolint:paralleltest
 	// t.Parallel()
 	testWithTimeout(t, 100*time.Millisecond)
@@ -458,3 +775,296 @@ func TestFanOut(t *testing.T) { //nolint:paralleltest
 		is.Zero(msg)
 	}
 }
+
+func TestFanInWithContext(t *testing.T) { //nolint:paralleltest
+	// t.Parallel()
+	testWithTimeout(t, 100*time.Millisecond)
+	is := assert.New(t)
+
+	upstream1 := SliceToChannel(0, []int{1, 2, 3})
+	upstream2 := SliceToChannel(0, []int{4, 5, 6})
+
+	out := FanInWithContext(context.Background(), 0, upstream1, upstream2)
+	is.ElementsMatch([]int{1, 2, 3, 4, 5, 6}, ChannelToSlice(out))
+
+	// the downstream channel is not read anymore
+	ctx, cancel := context.WithCancel(context.Background())
+	never := make(chan int)
+	busy := make(chan int)
+	go func() {
+		for i := 0; ; i++ {
+			select {
+			case busy <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	out = FanInWithContext(ctx, 1, never, busy)
+	for len(out) != 1 {
+		runtime.Gosched()
+	}
+
+	cancel()
+
+	// the buffered messages can still be read, then the channel is closed
+	is.NotEmpty(ChannelToSlice(out))
+}
+
+func TestFanOutWithContext(t *testing.T) { //nolint:paralleltest
+	// t.Parallel()
+	testWithTimeout(t, 100*time.Millisecond)
+	is := assert.New(t)
+
+	// a downstream channel is not read anymore
+	ctx, cancel := context.WithCancel(context.Background())
+	upstream := make(chan int)
+	downstreams := FanOutWithContext(ctx, 2, 1, upstream, FanOutOptions{})
+	is.Len(downstreams, 2)
+
+	upstream <- 1
+	is.Equal(1, <-downstreams[0])
+	upstream <- 2
+	for len(downstreams[0]) != 1 {
+		runtime.Gosched()
+	}
+
+	// the broadcast is paused: downstreams[1] is full
+	select {
+	case upstream <- 3:
+		is.Fail("broadcast should be paused")
+	default:
+	}
+
+	cancel()
+
+	is.Equal([]int{2}, ChannelToSlice(downstreams[0]))
+	is.Equal([]int{1}, ChannelToSlice(downstreams[1]))
+	close(upstream)
+
+	// slow subscribers are skipped
+	upstream2 := make(chan int)
+	downstreams = FanOutWithContext(context.Background(), 2, 2, upstream2, FanOutOptions{SkipSlowSubscribers: true})
+
+	for i := 0; i < 5; i++ {
+		upstream2 <- i
+		is.Equal(i, <-downstreams[0])
+	}
+
+	close(upstream2)
+
+	is.Empty(ChannelToSlice(downstreams[0]))
+	is.Equal([]int{0, 1}, ChannelToSlice(downstreams[1]))
+}
+
+func TestBroadcaster(t *testing.T) { //nolint:paralleltest
+	// t.Parallel()
+	testWithTimeout(t, 100*time.Millisecond)
+	is := assert.New(t)
+
+	b := NewBroadcaster[int](BroadcasterOptions{})
+
+	// no subscriber
+	is.NoError(b.Publish(0))
+
+	sub1, unsubscribe1 := b.Subscribe(SubscribeOptions{BufferSize: 10})
+	is.NoError(b.Publish(1))
+
+	sub2, unsubscribe2 := b.Subscribe(SubscribeOptions{BufferSize: 10})
+	is.Equal(2, b.Subscribers())
+	is.NoError(b.Publish(2))
+
+	unsubscribe1()
+	unsubscribe1()
+	is.Equal(1, b.Subscribers())
+	is.NoError(b.Publish(3))
+
+	is.Equal([]int{1, 2}, ChannelToSlice(sub1))
+
+	b.Close()
+	b.Close()
+	unsubscribe2()
+	is.Equal(0, b.Subscribers())
+	is.Equal([]int{2, 3}, ChannelToSlice(sub2))
+
+	is.ErrorIs(b.Publish(4), ErrBroadcasterClosed)
+
+	sub3, unsubscribe3 := b.Subscribe(SubscribeOptions{BufferSize: 10})
+	unsubscribe3()
+	is.Empty(ChannelToSlice(sub3))
+}
+
+func TestBroadcasterOverflow(t *testing.T) { //nolint:paralleltest
+	// t.Parallel()
+	testWithTimeout(t, 100*time.Millisecond)
+	is := assert.New(t)
+
+	b := NewBroadcaster[int](BroadcasterOptions{})
+
+	dropOldest, _ := b.Subscribe(SubscribeOptions{BufferSize: 2, Overflow: OverflowDropOldest})
+	dropNewest, _ := b.Subscribe(SubscribeOptions{BufferSize: 2, Overflow: OverflowDropNewest})
+	unbuffered, _ := b.Subscribe(SubscribeOptions{Overflow: OverflowDropOldest})
+
+	for i := 0; i < 5; i++ {
+		is.NoError(b.Publish(i))
+	}
+
+	b.Close()
+
+	is.Equal([]int{3, 4}, ChannelToSlice(dropOldest))
+	is.Equal([]int{0, 1}, ChannelToSlice(dropNewest))
+	is.Empty(ChannelToSlice(unbuffered))
+
+	// a blocking subscriber pauses the broadcast
+	b = NewBroadcaster[int](BroadcasterOptions{})
+	blocking, _ := b.Subscribe(SubscribeOptions{BufferSize: 1})
+
+	done := make(chan error)
+	go func() {
+		for i := 0; i < 3; i++ {
+			if err := b.Publish(i); err != nil {
+				done <- err
+				return
+			}
+		}
+
+		done <- nil
+	}()
+
+	is.Equal(0, <-blocking)
+	is.Equal(1, <-blocking)
+	is.Equal(2, <-blocking)
+	is.NoError(<-done)
+	b.Close()
+}
+
+func TestBroadcasterReplay(t *testing.T) { //nolint:paralleltest
+	// t.Parallel()
+	testWithTimeout(t, 100*time.Millisecond)
+	is := assert.New(t)
+
+	b := NewBroadcaster[int](BroadcasterOptions{Replay: 3})
+
+	for i := 0; i < 5; i++ {
+		is.NoError(b.Publish(i))
+	}
+
+	sub1, _ := b.Subscribe(SubscribeOptions{BufferSize: 10})
+	sub2, _ := b.Subscribe(SubscribeOptions{BufferSize: 2, Overflow: OverflowDropOldest})
+	sub3, _ := b.Subscribe(SubscribeOptions{BufferSize: 2, Overflow: OverflowDropNewest})
+
+	is.NoError(b.Publish(5))
+	b.Close()
+
+	is.Equal([]int{2, 3, 4, 5}, ChannelToSlice(sub1))
+	is.Equal([]int{4, 5}, ChannelToSlice(sub2))
+	is.Equal([]int{3, 4}, ChannelToSlice(sub3))
+}
+
+func TestBroadcasterPublishWithContext(t *testing.T) { //nolint:paralleltest
+	// t.Parallel()
+	testWithTimeout(t, 100*time.Millisecond)
+	is := assert.New(t)
+
+	b := NewBroadcaster[int](BroadcasterOptions{})
+	_, unsubscribeSlow := b.Subscribe(SubscribeOptions{})
+
+	// canceled while the blocking subscriber is full
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- b.PublishWithContext(ctx, 1)
+	}()
+
+	cancel()
+	is.ErrorIs(<-done, context.Canceled)
+
+	// unblocked when the blocking subscriber leaves
+	go func() {
+		done <- b.Publish(2)
+	}()
+
+	unsubscribeSlow()
+	is.NoError(<-done)
+	is.Equal(0, b.Subscribers())
+
+	// unblocked when the broadcaster is closed
+	_, _ = b.Subscribe(SubscribeOptions{})
+	go func() {
+		done <- b.Publish(3)
+	}()
+
+	b.Close()
+	is.ErrorIs(<-done, ErrBroadcasterClosed)
+
+	// a full subscriber does not block the subscriptions
+	b = NewBroadcaster[int](BroadcasterOptions{})
+	fast, _ := b.Subscribe(SubscribeOptions{BufferSize: 10})
+	slow, unsubscribeSlow := b.Subscribe(SubscribeOptions{})
+
+	go func() {
+		done <- b.Publish(4)
+	}()
+
+	// the publication is now sending to the slow subscriber
+	is.Equal(4, <-fast)
+
+	_, unsubscribe := b.Subscribe(SubscribeOptions{BufferSize: 10})
+	is.Equal(3, b.Subscribers())
+	unsubscribe()
+
+	is.Equal(4, <-slow)
+	is.NoError(<-done)
+
+	// unsubscribing does not close the channel during a send
+	go func() {
+		done <- b.Publish(5)
+	}()
+
+	is.Equal(5, <-fast)
+	unsubscribeSlow()
+	is.NoError(<-done)
+	is.Empty(ChannelToSlice(slow))
+
+	b.Close()
+	is.Empty(ChannelToSlice(fast))
+}
+
+func TestChanTimeWindow(t *testing.T) { //nolint:paralleltest
+	// t.Parallel()
+	testWithTimeout(t, 100*time.Millisecond)
+	is := assert.New(t)
+
+	origin := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(seconds int) time.Time {
+		return origin.Add(time.Duration(seconds) * time.Second)
+	}
+
+	windows := NewTumblingWindows(10*time.Second, func(agg int, item time.Time) int {
+		return agg + 1
+	}, 0, TimeWindowOptions[time.Time]{
+		Timestamp: func(item time.Time) time.Time {
+			return item
+		},
+	})
+
+	upstream := SliceToChannel(0, []time.Time{at(1), at(2), at(11), at(25)})
+	is.Equal([]TimeWindow[int]{
+		{Start: at(0), End: at(10), Count: 2, Value: 2},
+		{Start: at(10), End: at(20), Count: 1, Value: 1},
+		{Start: at(20), End: at(30), Count: 1, Value: 1},
+	}, ChannelToSlice(ChanTimeWindow(context.Background(), upstream, windows)))
+
+	// the open windows are dropped on cancellation
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := make(chan time.Time)
+	out := ChanTimeWindow(ctx, ch, NewSessionWindows(time.Second, func(agg int, item time.Time) int {
+		return agg + 1
+	}, 0, TimeWindowOptions[time.Time]{}))
+
+	ch <- at(0)
+	cancel()
+
+	is.Empty(ChannelToSlice(out))
+}
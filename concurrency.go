@@ -2,8 +2,13 @@ package lo
 
 import (
 	"context"
+	"errors"
+	"runtime"
 	"sync"
+	"sync/atomic"
 	"time"
+
+	"github.com/samber/lo/internal/xerrors"
 )
 
 type synchronize struct {
@@ -30,6 +35,217 @@ func Synchronize(opt ...sync.Locker) *synchronize { //nolint:revive
 	}
 }
 
+type keyedLock struct {
+	// refs counts the goroutines holding or waiting for the lock, which is dropped when it reaches 0
+	refs           int
+	readers        int
+	writer         bool
+	writersWaiting int
+	// changed is closed, and replaced, every time the lock is released
+	changed chan struct{}
+}
+
+func (l *keyedLock) acquire(write bool) bool {
+	if write {
+		if l.writer || l.readers > 0 {
+			return false
+		}
+
+		l.writer = true
+		return true
+	}
+
+	// pending writers have priority over new readers
+	if l.writer || l.writersWaiting > 0 {
+		return false
+	}
+
+	l.readers++
+	return true
+}
+
+func (l *keyedLock) notify() {
+	close(l.changed)
+	l.changed = make(chan struct{})
+}
+
+// keyedLocker holds a read/write lock per key, created on demand and dropped once unused.
+type keyedLocker[K comparable] struct {
+	mu    sync.Mutex
+	locks map[K]*keyedLock
+}
+
+// lock acquires the lock of a key. When block is false, it waits at most timeout and returns false
+// if the lock could not be acquired in time.
+func (l *keyedLocker[K]) lock(key K, write, block bool, timeout time.Duration) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.locks == nil {
+		l.locks = map[K]*keyedLock{}
+	}
+
+	entry, ok := l.locks[key]
+	if !ok {
+		entry = &keyedLock{changed: make(chan struct{})}
+		l.locks[key] = entry
+	}
+
+	entry.refs++
+	if write {
+		entry.writersWaiting++
+	}
+
+	acquired := l.wait(entry, write, block, timeout)
+
+	if write {
+		entry.writersWaiting--
+	}
+
+	if !acquired {
+		if write {
+			// readers waiting for this writer may proceed
+			entry.notify()
+		}
+
+		l.release(key, entry)
+	}
+
+	return acquired
+}
+
+// wait must be called with l.mu held, which is released while waiting.
+func (l *keyedLocker[K]) wait(entry *keyedLock, write, block bool, timeout time.Duration) bool {
+	if entry.acquire(write) {
+		return true
+	}
+
+	var deadline <-chan time.Time
+
+	if !block {
+		if timeout <= 0 {
+			return false
+		}
+
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+
+	for {
+		changed := entry.changed
+		l.mu.Unlock()
+
+		select {
+		case <-changed:
+			l.mu.Lock()
+		case <-deadline:
+			l.mu.Lock()
+			return false
+		}
+
+		if entry.acquire(write) {
+			return true
+		}
+	}
+}
+
+func (l *keyedLocker[K]) unlock(key K, write bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entry := l.locks[key]
+	if write {
+		entry.writer = false
+	} else {
+		entry.readers--
+	}
+
+	entry.notify()
+	l.release(key, entry)
+}
+
+func (l *keyedLocker[K]) release(key K, entry *keyedLock) {
+	entry.refs--
+	if entry.refs == 0 {
+		delete(l.locks, key)
+	}
+}
+
+func (l *keyedLocker[K]) do(key K, write bool, callback func()) {
+	l.lock(key, write, true, 0)
+	defer l.unlock(key, write)
+
+	callback()
+}
+
+func (l *keyedLocker[K]) tryDo(key K, write bool, timeout time.Duration, callback func()) bool {
+	if !l.lock(key, write, false, timeout) {
+		return false
+	}
+	defer l.unlock(key, write)
+
+	callback()
+
+	return true
+}
+
+// SynchronizeBy holds a mutex per key. Mutexes are created on demand and dropped once no goroutine
+// holds or waits for them. The zero value is ready to use.
+type SynchronizeBy[K comparable] struct {
+	locker keyedLocker[K]
+}
+
+// NewSynchronizeBy creates a SynchronizeBy.
+func NewSynchronizeBy[K comparable]() *SynchronizeBy[K] {
+	return &SynchronizeBy[K]{}
+}
+
+// Do executes the callback while holding the mutex of the key.
+func (s *SynchronizeBy[K]) Do(key K, callback func()) {
+	s.locker.do(key, true, callback)
+}
+
+// TryDo executes the callback if the mutex of the key can be acquired within timeout, and returns
+// whether the callback was executed. A timeout lower than or equal to 0 does not wait.
+func (s *SynchronizeBy[K]) TryDo(key K, timeout time.Duration, callback func()) bool {
+	return s.locker.tryDo(key, true, timeout, callback)
+}
+
+// RWSynchronizeBy holds a read/write lock per key. Locks are created on demand and dropped once
+// no goroutine holds or waits for them. Waiting writers have priority over new readers.
+// The zero value is ready to use.
+type RWSynchronizeBy[K comparable] struct {
+	locker keyedLocker[K]
+}
+
+// NewRWSynchronizeBy creates a RWSynchronizeBy.
+func NewRWSynchronizeBy[K comparable]() *RWSynchronizeBy[K] {
+	return &RWSynchronizeBy[K]{}
+}
+
+// Do executes the callback while holding the write lock of the key.
+func (s *RWSynchronizeBy[K]) Do(key K, callback func()) {
+	s.locker.do(key, true, callback)
+}
+
+// RDo executes the callback while holding the read lock of the key.
+func (s *RWSynchronizeBy[K]) RDo(key K, callback func()) {
+	s.locker.do(key, false, callback)
+}
+
+// TryDo executes the callback if the write lock of the key can be acquired within timeout, and
+// returns whether the callback was executed. A timeout lower than or equal to 0 does not wait.
+func (s *RWSynchronizeBy[K]) TryDo(key K, timeout time.Duration, callback func()) bool {
+	return s.locker.tryDo(key, true, timeout, callback)
+}
+
+// TryRDo executes the callback if the read lock of the key can be acquired within timeout, and
+// returns whether the callback was executed. A timeout lower than or equal to 0 does not wait.
+func (s *RWSynchronizeBy[K]) TryRDo(key K, timeout time.Duration, callback func()) bool {
+	return s.locker.tryDo(key, false, timeout, callback)
+}
+
 // Async executes a function in a goroutine and returns the result in a channel.
 // Play: https://go.dev/play/p/uo35gosuTLw
 func Async[A any](f func() A) <-chan A {
@@ -145,3 +361,608 @@ func WaitForWithContext(ctx context.Context, condition func(ctx context.Context,
 		}
 	}
 }
+
+type singleflightCall[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+// Singleflight collapses concurrent calls sharing the same key into a single execution:
+// while a call is in flight, other callers for the same key wait for it and receive its result.
+// The zero value is ready to use.
+type Singleflight[K comparable, V any] struct {
+	mu    sync.Mutex
+	calls map[K]*singleflightCall[V]
+}
+
+// NewSingleflight creates a Singleflight.
+func NewSingleflight[K comparable, V any]() *Singleflight[K, V] {
+	return &Singleflight[K, V]{
+		calls: map[K]*singleflightCall[V]{},
+	}
+}
+
+// Do executes and returns the result of fn, making sure only one execution is in flight for
+// a given key at a time. Duplicate callers wait for the in-flight call and share its value and error.
+// A panic in fn is returned as an error to every caller.
+func (s *Singleflight[K, V]) Do(key K, fn func() (V, error)) (V, error) {
+	call, leader := s.join(key)
+	if leader {
+		s.execute(key, call, fn)
+	} else {
+		<-call.done
+	}
+
+	return call.value, call.err
+}
+
+// DoWithContext is like Do, but the caller stops waiting when the context is canceled and
+// returns ctx.Err(). The call keeps running in the background for the other callers,
+// so fn does not receive the context of any caller.
+func (s *Singleflight[K, V]) DoWithContext(ctx context.Context, key K, fn func() (V, error)) (V, error) {
+	call, leader := s.join(key)
+	if leader {
+		go s.execute(key, call, fn)
+	}
+
+	select {
+	case <-call.done:
+		return call.value, call.err
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+// Refresh executes fn even if a call is already in flight for the key. Callers arriving after
+// Refresh wait for the new call, while the callers of the previous call keep their own result.
+func (s *Singleflight[K, V]) Refresh(key K, fn func() (V, error)) (V, error) {
+	call := &singleflightCall[V]{done: make(chan struct{})}
+
+	s.mu.Lock()
+	if s.calls == nil {
+		s.calls = map[K]*singleflightCall[V]{}
+	}
+	s.calls[key] = call
+	s.mu.Unlock()
+
+	s.execute(key, call, fn)
+
+	return call.value, call.err
+}
+
+// Forget drops the in-flight call for the key, so that the next caller starts a new execution
+// instead of waiting. Callers already waiting still receive the result of the forgotten call.
+func (s *Singleflight[K, V]) Forget(key K) {
+	s.mu.Lock()
+	delete(s.calls, key)
+	s.mu.Unlock()
+}
+
+// InFlight returns true if a call is in flight for the key.
+func (s *Singleflight[K, V]) InFlight(key K) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, ok := s.calls[key]
+	return ok
+}
+
+// join returns the in-flight call for the key, or registers a new one. The caller that
+// registered the call is the leader and must execute it.
+func (s *Singleflight[K, V]) join(key K) (*singleflightCall[V], bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if call, ok := s.calls[key]; ok {
+		return call, false
+	}
+
+	if s.calls == nil {
+		s.calls = map[K]*singleflightCall[V]{}
+	}
+
+	call := &singleflightCall[V]{done: make(chan struct{})}
+	s.calls[key] = call
+
+	return call, true
+}
+
+func (s *Singleflight[K, V]) execute(key K, call *singleflightCall[V], fn func() (V, error)) {
+	defer func() {
+		if r := recover(); r != nil {
+			call.err = recoverToError("lo.Singleflight", r)
+		}
+
+		s.mu.Lock()
+		if s.calls[key] == call {
+			delete(s.calls, key)
+		}
+		s.mu.Unlock()
+
+		close(call.done)
+	}()
+
+	call.value, call.err = fn()
+}
+
+// ErrNoFutures is returned by FutureAny and FutureRace when no future is given.
+var ErrNoFutures = errors.New("lo.Future: no futures")
+
+// ErrFutureChannelClosed is returned by a future created with NewFutureFromChannel when the channel
+// is closed without receiving a value.
+var ErrFutureChannelClosed = errors.New("lo.Future: channel closed")
+
+// Future is the result of an asynchronous computation, resolved with a value or rejected with an error.
+type Future[T any] struct {
+	done  chan struct{}
+	value T
+	err   error
+}
+
+// NewFuture executes a function in a goroutine and returns a future of its result.
+// A panic in the function rejects the future with an error.
+func NewFuture[T any](f func() (T, error)) *Future[T] {
+	future := &Future[T]{done: make(chan struct{})}
+
+	go future.run(f)
+
+	return future
+}
+
+// NewFutureFromChannel returns a future resolved with the first value received from the channel,
+// such as the channel returned by Async. The future is rejected with ErrFutureChannelClosed
+// if the channel is closed first.
+func NewFutureFromChannel[T any](ch <-chan T) *Future[T] {
+	return NewFuture(func() (T, error) {
+		value, ok := <-ch
+		if !ok {
+			return value, ErrFutureChannelClosed
+		}
+
+		return value, nil
+	})
+}
+
+func (f *Future[T]) run(fn func() (T, error)) {
+	defer func() {
+		if r := recover(); r != nil {
+			var zero T
+			f.value = zero
+			f.err = recoverToError("lo.Future", r)
+		}
+
+		close(f.done)
+	}()
+
+	f.value, f.err = fn()
+}
+
+// Await waits for the future and returns its value and error. It returns ctx.Err() when the
+// context is canceled first, and the computation keeps running.
+func (f *Future[T]) Await(ctx context.Context) (T, error) {
+	select {
+	case <-f.done:
+		return f.value, f.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// Done returns a channel closed when the future is resolved or rejected.
+func (f *Future[T]) Done() <-chan struct{} {
+	return f.done
+}
+
+// Chan returns a channel receiving the value and the error of the future, like Async2.
+func (f *Future[T]) Chan() <-chan Tuple2[T, error] {
+	ch := make(chan Tuple2[T, error], 1)
+
+	go func() {
+		<-f.done
+		ch <- Tuple2[T, error]{A: f.value, B: f.err}
+		close(ch)
+	}()
+
+	return ch
+}
+
+// Then returns a future resolved with the result of fn applied to the value of this future.
+// fn is not called when this future is rejected, and the error is propagated.
+func (f *Future[T]) Then(fn func(value T) (T, error)) *Future[T] {
+	return FutureThen(f, fn)
+}
+
+// Catch returns a future resolved with the value of this future, or with the result of fn
+// applied to the error when this future is rejected.
+func (f *Future[T]) Catch(fn func(err error) (T, error)) *Future[T] {
+	return NewFuture(func() (T, error) {
+		<-f.done
+
+		if f.err == nil {
+			return f.value, nil
+		}
+
+		return fn(f.err)
+	})
+}
+
+// FutureThen is like Future.Then, but fn may return a value of another type.
+func FutureThen[T, R any](future *Future[T], fn func(value T) (R, error)) *Future[R] {
+	return NewFuture(func() (R, error) {
+		<-future.done
+
+		if future.err != nil {
+			var zero R
+			return zero, future.err
+		}
+
+		return fn(future.value)
+	})
+}
+
+// futuresSettled returns a channel receiving the index, value and error of each future, in the order they settle.
+func futuresSettled[T any](futures []*Future[T]) <-chan Tuple3[int, T, error] {
+	ch := make(chan Tuple3[int, T, error], len(futures))
+
+	for i := range futures {
+		go func(i int) {
+			<-futures[i].done
+			ch <- Tuple3[int, T, error]{A: i, B: futures[i].value, C: futures[i].err}
+		}(i)
+	}
+
+	return ch
+}
+
+// FutureAll returns a future resolved with the values of all the futures, in the same order,
+// or rejected with the first error.
+func FutureAll[T any](futures ...*Future[T]) *Future[[]T] {
+	return NewFuture(func() ([]T, error) {
+		values := make([]T, len(futures))
+		settled := futuresSettled(futures)
+
+		for range futures {
+			result := <-settled
+			if result.C != nil {
+				return nil, result.C
+			}
+
+			values[result.A] = result.B
+		}
+
+		return values, nil
+	})
+}
+
+// FutureAllSettled returns a future resolved with the value and the error of all the futures,
+// in the same order, once they all settled.
+func FutureAllSettled[T any](futures ...*Future[T]) *Future[[]Tuple2[T, error]] {
+	return NewFuture(func() ([]Tuple2[T, error], error) {
+		results := make([]Tuple2[T, error], len(futures))
+
+		for i := range futures {
+			<-futures[i].done
+			results[i] = Tuple2[T, error]{A: futures[i].value, B: futures[i].err}
+		}
+
+		return results, nil
+	})
+}
+
+// FutureAny returns a future resolved with the first value among the futures, or rejected
+// with all the errors when every future is rejected.
+func FutureAny[T any](futures ...*Future[T]) *Future[T] {
+	return NewFuture(func() (T, error) {
+		var zero T
+
+		if len(futures) == 0 {
+			return zero, ErrNoFutures
+		}
+
+		errs := make([]error, len(futures))
+		settled := futuresSettled(futures)
+
+		for range futures {
+			result := <-settled
+			if result.C == nil {
+				return result.B, nil
+			}
+
+			errs[result.A] = result.C
+		}
+
+		return zero, xerrors.Join(errs...)
+	})
+}
+
+// FutureRace returns a future settled like the first settled future among the futures.
+func FutureRace[T any](futures ...*Future[T]) *Future[T] {
+	return NewFuture(func() (T, error) {
+		if len(futures) == 0 {
+			var zero T
+			return zero, ErrNoFutures
+		}
+
+		result := <-futuresSettled(futures)
+		return result.B, result.C
+	})
+}
+
+type semaphoreWaiter struct {
+	n     int
+	ready chan struct{}
+}
+
+// Semaphore is a weighted semaphore. Waiters are served in the order they called Acquire,
+// so that a large request is not starved by smaller ones.
+type Semaphore struct {
+	mu      sync.Mutex
+	size    int
+	cur     int
+	waiters []*semaphoreWaiter
+}
+
+// NewSemaphore creates a semaphore with the given total weight.
+func NewSemaphore(size int) *Semaphore {
+	return &Semaphore{size: size}
+}
+
+// Acquire acquires a weight of n, blocking until it is available or the context is done.
+// On failure, it returns ctx.Err() and leaves the semaphore unchanged.
+func (s *Semaphore) Acquire(ctx context.Context, n int) error {
+	s.mu.Lock()
+
+	if len(s.waiters) == 0 && s.cur+n <= s.size {
+		s.cur += n
+		s.mu.Unlock()
+		return nil
+	}
+
+	if n > s.size {
+		// the weight can never be acquired
+		s.mu.Unlock()
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	waiter := &semaphoreWaiter{n: n, ready: make(chan struct{})}
+	s.waiters = append(s.waiters, waiter)
+	s.mu.Unlock()
+
+	select {
+	case <-waiter.ready:
+		return nil
+	case <-ctx.Done():
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		select {
+		case <-waiter.ready:
+			// acquired while being canceled: give the weight back
+			s.cur -= n
+		default:
+			for i := range s.waiters {
+				if s.waiters[i] == waiter {
+					s.waiters = append(s.waiters[:i], s.waiters[i+1:]...)
+					break
+				}
+			}
+		}
+
+		s.notifyWaiters()
+
+		return ctx.Err()
+	}
+}
+
+// TryAcquire acquires a weight of n without blocking, and returns whether it succeeded.
+func (s *Semaphore) TryAcquire(n int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.waiters) == 0 && s.cur+n <= s.size {
+		s.cur += n
+		return true
+	}
+
+	return false
+}
+
+// Release releases a weight of n. It panics when releasing more than held.
+func (s *Semaphore) Release(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.cur -= n
+	if s.cur < 0 {
+		panic("lo.Semaphore: released more than held")
+	}
+
+	s.notifyWaiters()
+}
+
+func (s *Semaphore) notifyWaiters() {
+	for len(s.waiters) > 0 {
+		waiter := s.waiters[0]
+		if s.cur+waiter.n > s.size {
+			return
+		}
+
+		s.cur += waiter.n
+		s.waiters = s.waiters[1:]
+		close(waiter.ready)
+	}
+}
+
+// QueuePolicy is the behavior of WorkerPool.Submit when the queue is full.
+type QueuePolicy int
+
+const (
+	// QueueBlock waits until the queue has room for the job.
+	QueueBlock QueuePolicy = iota
+	// QueueDrop discards the job.
+	QueueDrop
+	// QueueError rejects the job with ErrQueueFull.
+	QueueError
+)
+
+// ErrQueueFull is returned by WorkerPool.Submit when the queue is full and the policy is QueueError.
+var ErrQueueFull = errors.New("lo.WorkerPool: queue is full")
+
+// ErrPoolShutdown is returned by WorkerPool.Submit once the pool is shut down.
+var ErrPoolShutdown = errors.New("lo.WorkerPool: pool is shut down")
+
+// WorkerPoolOptions configures a WorkerPool.
+type WorkerPoolOptions struct {
+	// Workers is the number of jobs executed at the same time. When lower than 1, it defaults to runtime.GOMAXPROCS(0).
+	Workers int
+	// QueueSize is the number of jobs waiting for a worker. Defaults to 0: a job is accepted only when a worker picks it.
+	QueueSize int
+	// QueuePolicy is the behavior of Submit when the queue is full. Defaults to QueueBlock.
+	QueuePolicy QueuePolicy
+}
+
+// WorkerPool executes jobs with a bounded number of workers and sends their results on a channel.
+type WorkerPool[T any] struct {
+	policy  QueuePolicy
+	jobs    chan func() (T, error)
+	results chan Tuple2[T, error]
+
+	mu       sync.RWMutex
+	shutdown bool
+	quit     chan struct{}
+	done     chan struct{}
+	once     sync.Once
+	dropped  int64
+}
+
+// NewWorkerPool starts a pool of workers. The results of the jobs are sent on the channel returned by
+// Results, in completion order, and must be consumed: workers wait for the results to be received.
+func NewWorkerPool[T any](options WorkerPoolOptions) *WorkerPool[T] {
+	workers := options.Workers
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	queueSize := options.QueueSize
+	if queueSize < 0 {
+		queueSize = 0
+	}
+
+	p := &WorkerPool[T]{
+		policy:  options.QueuePolicy,
+		jobs:    make(chan func() (T, error), queueSize),
+		results: make(chan Tuple2[T, error]),
+		quit:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+
+			for job := range p.jobs {
+				p.results <- p.execute(job)
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(p.results)
+		close(p.done)
+	}()
+
+	return p
+}
+
+func (p *WorkerPool[T]) execute(job func() (T, error)) (result Tuple2[T, error]) {
+	defer func() {
+		if r := recover(); r != nil {
+			result = Tuple2[T, error]{B: recoverToError("lo.WorkerPool", r)}
+		}
+	}()
+
+	value, err := job()
+
+	return Tuple2[T, error]{A: value, B: err}
+}
+
+// Submit queues a job. When the queue is full, it blocks, drops the job or returns ErrQueueFull,
+// depending on the queue policy. It returns ErrPoolShutdown once Shutdown was called.
+func (p *WorkerPool[T]) Submit(job func() (T, error)) error {
+	return p.SubmitWithContext(context.Background(), job)
+}
+
+// SubmitWithContext is like Submit, but stops waiting for room in the queue when the context
+// is canceled, and returns ctx.Err().
+func (p *WorkerPool[T]) SubmitWithContext(ctx context.Context, job func() (T, error)) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.shutdown {
+		return ErrPoolShutdown
+	}
+
+	select {
+	case p.jobs <- job:
+		return nil
+	default:
+	}
+
+	switch p.policy {
+	case QueueDrop:
+		atomic.AddInt64(&p.dropped, 1)
+		return nil
+	case QueueError:
+		return ErrQueueFull
+	case QueueBlock:
+	}
+
+	select {
+	case p.jobs <- job:
+		return nil
+	case <-p.quit:
+		return ErrPoolShutdown
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Results returns the channel receiving the value and the error of each job. It is closed once
+// the pool is shut down and every queued job is done.
+func (p *WorkerPool[T]) Results() <-chan Tuple2[T, error] {
+	return p.results
+}
+
+// Dropped returns the number of jobs discarded by the QueueDrop policy.
+func (p *WorkerPool[T]) Dropped() int {
+	return int(atomic.LoadInt64(&p.dropped))
+}
+
+// Shutdown stops accepting jobs and waits for the queued jobs to be done. When the context is
+// done first, it returns ctx.Err() and the remaining jobs keep running in the background.
+func (p *WorkerPool[T]) Shutdown(ctx context.Context) error {
+	p.once.Do(func() {
+		// unblock the pending submissions before closing the queue
+		close(p.quit)
+
+		p.mu.Lock()
+		p.shutdown = true
+		close(p.jobs)
+		p.mu.Unlock()
+	})
+
+	select {
+	case <-p.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -2,7 +2,11 @@ package lo
 
 import (
 	"context"
+	"errors"
+	"sort"
+	"strconv"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -68,6 +72,219 @@ func TestSynchronize(t *testing.T) { //nolint:paralleltest
 	})
 }
 
+func TestSynchronizeBy(t *testing.T) { //nolint:paralleltest
+	// t.Parallel()
+	testWithTimeout(t, time.Second)
+
+	t.Run("callbacks of a key are not executed concurrently", func(t *testing.T) { //nolint:paralleltest
+		// t.Parallel()
+		is := assert.New(t)
+
+		s := NewSynchronizeBy[int]()
+
+		var running, maxRunning [3]int32
+		var wg sync.WaitGroup
+
+		for i := 0; i < 30; i++ {
+			wg.Add(1)
+			go func(key int) {
+				defer wg.Done()
+
+				s.Do(key, func() {
+					n := atomic.AddInt32(&running[key], 1)
+					if n > atomic.LoadInt32(&maxRunning[key]) {
+						atomic.StoreInt32(&maxRunning[key], n)
+					}
+					time.Sleep(time.Millisecond)
+					atomic.AddInt32(&running[key], -1)
+				})
+			}(i % 3)
+		}
+
+		wg.Wait()
+
+		is.Equal([3]int32{1, 1, 1}, maxRunning)
+
+		// unused locks are dropped
+		is.Empty(s.locker.locks)
+	})
+
+	t.Run("keys are independent", func(t *testing.T) { //nolint:paralleltest
+		// t.Parallel()
+		is := assert.New(t)
+
+		var s SynchronizeBy[string]
+
+		s.Do("a", func() {
+			s.Do("b", func() {
+				is.Len(s.locker.locks, 2)
+			})
+		})
+
+		is.Empty(s.locker.locks)
+	})
+
+	t.Run("try do", func(t *testing.T) { //nolint:paralleltest
+		// t.Parallel()
+		is := assert.New(t)
+
+		s := NewSynchronizeBy[string]()
+
+		locked := make(chan struct{})
+		release := make(chan struct{})
+		done := make(chan struct{})
+
+		go func() {
+			s.Do("a", func() {
+				close(locked)
+				<-release
+			})
+			close(done)
+		}()
+
+		<-locked
+
+		is.False(s.TryDo("a", 0, func() {}))
+
+		start := time.Now()
+		is.False(s.TryDo("a", 20*time.Millisecond, func() {}))
+		is.GreaterOrEqual(time.Since(start), 20*time.Millisecond)
+
+		is.True(s.TryDo("b", 0, func() {}))
+
+		time.AfterFunc(10*time.Millisecond, func() { close(release) })
+
+		called := false
+		is.True(s.TryDo("a", time.Second, func() { called = true }))
+		is.True(called)
+
+		<-done
+		is.Empty(s.locker.locks)
+	})
+
+	t.Run("panic releases the lock", func(t *testing.T) { //nolint:paralleltest
+		// t.Parallel()
+		is := assert.New(t)
+
+		s := NewSynchronizeBy[string]()
+
+		is.Panics(func() {
+			s.Do("a", func() {
+				panic("boom")
+			})
+		})
+
+		is.True(s.TryDo("a", 0, func() {}))
+		is.Empty(s.locker.locks)
+	})
+}
+
+func TestRWSynchronizeBy(t *testing.T) { //nolint:paralleltest
+	// t.Parallel()
+	testWithTimeout(t, time.Second)
+
+	t.Run("readers share the lock", func(t *testing.T) { //nolint:paralleltest
+		// t.Parallel()
+		is := assert.New(t)
+
+		s := NewRWSynchronizeBy[string]()
+
+		s.RDo("a", func() {
+			is.True(s.TryRDo("a", 0, func() {}))
+			is.False(s.TryDo("a", 0, func() {}))
+			is.True(s.TryDo("b", 0, func() {}))
+		})
+
+		s.Do("a", func() {
+			is.False(s.TryRDo("a", 0, func() {}))
+			is.False(s.TryDo("a", 0, func() {}))
+		})
+
+		is.Empty(s.locker.locks)
+	})
+
+	t.Run("waiting writers have priority", func(t *testing.T) { //nolint:paralleltest
+		// t.Parallel()
+		is := assert.New(t)
+
+		s := NewRWSynchronizeBy[string]()
+
+		reading := make(chan struct{})
+		release := make(chan struct{})
+		var wg sync.WaitGroup
+		var order []string
+		var mu sync.Mutex
+
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			s.RDo("a", func() {
+				close(reading)
+				<-release
+			})
+		}()
+
+		<-reading
+
+		go func() {
+			defer wg.Done()
+			s.Do("a", func() {
+				mu.Lock()
+				order = append(order, "writer")
+				mu.Unlock()
+			})
+		}()
+
+		// wait for the writer to queue
+		is.Eventually(func() bool {
+			s.locker.mu.Lock()
+			defer s.locker.mu.Unlock()
+			return s.locker.locks["a"].writersWaiting == 1
+		}, time.Second, time.Millisecond)
+
+		is.False(s.TryRDo("a", 10*time.Millisecond, func() {}))
+
+		close(release)
+
+		s.RDo("a", func() {
+			mu.Lock()
+			order = append(order, "reader")
+			mu.Unlock()
+		})
+
+		wg.Wait()
+
+		is.Equal([]string{"writer", "reader"}, order)
+		is.Empty(s.locker.locks)
+	})
+
+	t.Run("timed out writer lets readers in", func(t *testing.T) { //nolint:paralleltest
+		// t.Parallel()
+		is := assert.New(t)
+
+		s := NewRWSynchronizeBy[string]()
+
+		s.RDo("a", func() {
+			done := make(chan bool)
+			go func() {
+				done <- s.TryDo("a", 20*time.Millisecond, func() {})
+			}()
+
+			is.Eventually(func() bool {
+				s.locker.mu.Lock()
+				defer s.locker.mu.Unlock()
+				return s.locker.locks["a"].writersWaiting == 1
+			}, time.Second, time.Millisecond)
+
+			// this reader waits for the writer to give up
+			is.True(s.TryRDo("a", time.Second, func() {}))
+			is.False(<-done)
+		})
+
+		is.Empty(s.locker.locks)
+	})
+}
+
 func TestAsync(t *testing.T) { //nolint:paralleltest
 	// t.Parallel()
 	testWithTimeout(t, 100*time.Millisecond)
@@ -403,3 +620,686 @@ func TestWaitForWithContext(t *testing.T) { //nolint:paralleltest
 		is.False(ok)
 	})
 }
+
+func TestSingleflight(t *testing.T) { //nolint:paralleltest
+	// t.Parallel()
+	testWithTimeout(t, time.Second)
+
+	t.Run("concurrent callers share the result", func(t *testing.T) { //nolint:paralleltest
+		// t.Parallel()
+		is := assert.New(t)
+
+		sf := NewSingleflight[string, int]()
+		release := make(chan struct{})
+		var calls int32
+
+		fn := func() (int, error) {
+			atomic.AddInt32(&calls, 1)
+			<-release
+			return 42, nil
+		}
+
+		var wg sync.WaitGroup
+		results := make([]int, 10)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[0], _ = sf.Do("key", fn)
+		}()
+
+		for !sf.InFlight("key") {
+			time.Sleep(time.Millisecond)
+		}
+
+		for i := 1; i < 10; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				results[i], _ = sf.Do("key", fn)
+			}(i)
+		}
+
+		time.Sleep(10 * time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		is.Equal(int32(1), atomic.LoadInt32(&calls))
+		is.Equal([]int{42, 42, 42, 42, 42, 42, 42, 42, 42, 42}, results)
+		is.False(sf.InFlight("key"))
+	})
+
+	t.Run("errors are shared and not cached", func(t *testing.T) { //nolint:paralleltest
+		// t.Parallel()
+		is := assert.New(t)
+
+		var sf Singleflight[int, string]
+		calls := 0
+
+		_, err := sf.Do(1, func() (string, error) {
+			calls++
+			return "", assert.AnError
+		})
+		is.ErrorIs(err, assert.AnError)
+
+		value, err := sf.Do(1, func() (string, error) {
+			calls++
+			return "ok", nil
+		})
+		is.NoError(err)
+		is.Equal("ok", value)
+		is.Equal(2, calls)
+	})
+
+	t.Run("different keys run concurrently", func(t *testing.T) { //nolint:paralleltest
+		// t.Parallel()
+		is := assert.New(t)
+
+		sf := NewSingleflight[int, int]()
+		started := make(chan struct{})
+		release := make(chan struct{})
+
+		go func() {
+			_, _ = sf.Do(1, func() (int, error) {
+				close(started)
+				<-release
+				return 1, nil
+			})
+		}()
+		<-started
+
+		value, err := sf.Do(2, func() (int, error) {
+			return 2, nil
+		})
+		is.NoError(err)
+		is.Equal(2, value)
+
+		close(release)
+	})
+
+	t.Run("panics are returned as errors", func(t *testing.T) { //nolint:paralleltest
+		// t.Parallel()
+		is := assert.New(t)
+
+		sf := NewSingleflight[int, int]()
+
+		_, err := sf.Do(1, func() (int, error) {
+			panic("boom")
+		})
+		is.EqualError(err, "lo.Singleflight: recovered from panic: boom")
+
+		_, err = sf.Do(1, func() (int, error) {
+			panic(assert.AnError)
+		})
+		is.ErrorIs(err, assert.AnError)
+		is.False(sf.InFlight(1))
+	})
+}
+
+func TestSingleflightDoWithContext(t *testing.T) { //nolint:paralleltest
+	// t.Parallel()
+	testWithTimeout(t, time.Second)
+	is := assert.New(t)
+
+	sf := NewSingleflight[string, int]()
+	release := make(chan struct{})
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		value, err := sf.Do("key", func() (int, error) {
+			<-release
+			return 42, nil
+		})
+		is.NoError(err)
+		is.Equal(42, value)
+	}()
+
+	for !sf.InFlight("key") {
+		time.Sleep(time.Millisecond)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	value, err := sf.DoWithContext(ctx, "key", func() (int, error) {
+		return 0, nil
+	})
+	is.ErrorIs(err, context.DeadlineExceeded)
+	is.Zero(value)
+
+	// the in-flight call is not affected by the canceled caller
+	close(release)
+	<-done
+
+	value, err = sf.DoWithContext(context.Background(), "key", func() (int, error) {
+		return 21, nil
+	})
+	is.NoError(err)
+	is.Equal(21, value)
+}
+
+func TestSingleflightForgetAndRefresh(t *testing.T) { //nolint:paralleltest
+	// t.Parallel()
+	testWithTimeout(t, time.Second)
+	is := assert.New(t)
+
+	sf := NewSingleflight[string, int]()
+	release := make(chan struct{})
+	done := make(chan int)
+
+	go func() {
+		value, _ := sf.Do("key", func() (int, error) {
+			<-release
+			return 1, nil
+		})
+		done <- value
+	}()
+
+	for !sf.InFlight("key") {
+		time.Sleep(time.Millisecond)
+	}
+
+	sf.Forget("key")
+	is.False(sf.InFlight("key"))
+
+	// a new execution starts instead of waiting for the forgotten call
+	value, err := sf.Do("key", func() (int, error) {
+		return 2, nil
+	})
+	is.NoError(err)
+	is.Equal(2, value)
+
+	value, err = sf.Refresh("key", func() (int, error) {
+		return 3, nil
+	})
+	is.NoError(err)
+	is.Equal(3, value)
+
+	close(release)
+	is.Equal(1, <-done)
+	is.False(sf.InFlight("key"))
+}
+
+func TestFuture(t *testing.T) { //nolint:paralleltest
+	// t.Parallel()
+	testWithTimeout(t, time.Second)
+
+	t.Run("resolved", func(t *testing.T) { //nolint:paralleltest
+		// t.Parallel()
+		is := assert.New(t)
+
+		future := NewFuture(func() (int, error) {
+			return 42, nil
+		})
+
+		value, err := future.Await(context.Background())
+		is.Equal(42, value)
+		is.NoError(err)
+
+		// await again
+		value, err = future.Await(context.Background())
+		is.Equal(42, value)
+		is.NoError(err)
+
+		<-future.Done()
+	})
+
+	t.Run("rejected", func(t *testing.T) { //nolint:paralleltest
+		// t.Parallel()
+		is := assert.New(t)
+
+		value, err := NewFuture(func() (int, error) {
+			return 0, assert.AnError
+		}).Await(context.Background())
+		is.Zero(value)
+		is.ErrorIs(err, assert.AnError)
+	})
+
+	t.Run("panic", func(t *testing.T) { //nolint:paralleltest
+		// t.Parallel()
+		is := assert.New(t)
+
+		_, err := NewFuture(func() (int, error) {
+			panic("boom")
+		}).Await(context.Background())
+		is.EqualError(err, "lo.Future: recovered from panic: boom")
+
+		_, err = NewFuture(func() (int, error) {
+			panic(assert.AnError)
+		}).Await(context.Background())
+		is.ErrorIs(err, assert.AnError)
+	})
+
+	t.Run("await with canceled context", func(t *testing.T) { //nolint:paralleltest
+		// t.Parallel()
+		is := assert.New(t)
+
+		release := make(chan struct{})
+		future := NewFuture(func() (int, error) {
+			<-release
+			return 42, nil
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		value, err := future.Await(ctx)
+		is.Zero(value)
+		is.ErrorIs(err, context.DeadlineExceeded)
+
+		close(release)
+
+		value, err = future.Await(context.Background())
+		is.Equal(42, value)
+		is.NoError(err)
+	})
+}
+
+func TestFutureChannels(t *testing.T) { //nolint:paralleltest
+	// t.Parallel()
+	testWithTimeout(t, time.Second)
+	is := assert.New(t)
+
+	value, err := NewFutureFromChannel(Async(func() int { return 42 })).Await(context.Background())
+	is.Equal(42, value)
+	is.NoError(err)
+
+	ch := make(chan int)
+	close(ch)
+	_, err = NewFutureFromChannel(ch).Await(context.Background())
+	is.ErrorIs(err, ErrFutureChannelClosed)
+
+	result := <-NewFuture(func() (string, error) { return "foo", assert.AnError }).Chan()
+	is.Equal(Tuple2[string, error]{A: "foo", B: assert.AnError}, result)
+}
+
+func TestFutureThenAndCatch(t *testing.T) { //nolint:paralleltest
+	// t.Parallel()
+	testWithTimeout(t, time.Second)
+	is := assert.New(t)
+
+	resolved := NewFuture(func() (int, error) { return 21, nil })
+	rejected := NewFuture(func() (int, error) { return 0, assert.AnError })
+
+	double := func(value int) (int, error) { return value * 2, nil }
+	recovery := func(err error) (int, error) { return -1, nil }
+
+	value, err := resolved.Then(double).Await(context.Background())
+	is.Equal(42, value)
+	is.NoError(err)
+
+	value, err = rejected.Then(double).Await(context.Background())
+	is.Zero(value)
+	is.ErrorIs(err, assert.AnError)
+
+	value, err = resolved.Catch(recovery).Await(context.Background())
+	is.Equal(21, value)
+	is.NoError(err)
+
+	value, err = rejected.Then(double).Catch(recovery).Await(context.Background())
+	is.Equal(-1, value)
+	is.NoError(err)
+
+	str, err := FutureThen(resolved, func(value int) (string, error) {
+		return strconv.Itoa(value), nil
+	}).Await(context.Background())
+	is.Equal("21", str)
+	is.NoError(err)
+
+	_, err = resolved.Then(func(int) (int, error) {
+		panic("boom")
+	}).Await(context.Background())
+	is.EqualError(err, "lo.Future: recovered from panic: boom")
+}
+
+func TestFutureCombinators(t *testing.T) { //nolint:paralleltest
+	// t.Parallel()
+	testWithTimeout(t, time.Second)
+
+	errFoo := errors.New("foo")
+	errBar := errors.New("bar")
+
+	// delayed returns a future settled once the returned function is called
+	delayed := func(value int, err error) (*Future[int], func()) {
+		release := make(chan struct{})
+		return NewFuture(func() (int, error) {
+			<-release
+			return value, err
+		}), func() { close(release) }
+	}
+
+	t.Run("all", func(t *testing.T) { //nolint:paralleltest
+		// t.Parallel()
+		is := assert.New(t)
+
+		f1, release1 := delayed(1, nil)
+		f2, release2 := delayed(2, nil)
+		release2()
+		release1()
+
+		values, err := FutureAll(f1, f2).Await(context.Background())
+		is.Equal([]int{1, 2}, values)
+		is.NoError(err)
+
+		f3, release3 := delayed(3, nil)
+		f4, release4 := delayed(0, errFoo)
+		release4()
+
+		values, err = FutureAll(f3, f4).Await(context.Background())
+		is.Nil(values)
+		is.ErrorIs(err, errFoo)
+		release3()
+
+		values, err = FutureAll[int]().Await(context.Background())
+		is.Equal([]int{}, values)
+		is.NoError(err)
+	})
+
+	t.Run("all settled", func(t *testing.T) { //nolint:paralleltest
+		// t.Parallel()
+		is := assert.New(t)
+
+		f1, release1 := delayed(1, nil)
+		f2, release2 := delayed(0, errFoo)
+		release2()
+		release1()
+
+		results, err := FutureAllSettled(f1, f2).Await(context.Background())
+		is.Equal([]Tuple2[int, error]{{A: 1}, {B: errFoo}}, results)
+		is.NoError(err)
+	})
+
+	t.Run("any", func(t *testing.T) { //nolint:paralleltest
+		// t.Parallel()
+		is := assert.New(t)
+
+		f1, release1 := delayed(1, nil)
+		f2, release2 := delayed(0, errFoo)
+		f3, release3 := delayed(3, nil)
+		release2()
+		release3()
+
+		value, err := FutureAny(f1, f2, f3).Await(context.Background())
+		is.Equal(3, value)
+		is.NoError(err)
+		release1()
+
+		f4, release4 := delayed(0, errFoo)
+		f5, release5 := delayed(0, errBar)
+		release5()
+		release4()
+
+		_, err = FutureAny(f4, f5).Await(context.Background())
+		is.ErrorIs(err, errFoo)
+		is.ErrorIs(err, errBar)
+		is.Equal("foo\nbar", err.Error())
+
+		_, err = FutureAny[int]().Await(context.Background())
+		is.ErrorIs(err, ErrNoFutures)
+	})
+
+	t.Run("race", func(t *testing.T) { //nolint:paralleltest
+		// t.Parallel()
+		is := assert.New(t)
+
+		f1, release1 := delayed(1, nil)
+		f2, release2 := delayed(0, errFoo)
+		release2()
+
+		value, err := FutureRace(f1, f2).Await(context.Background())
+		is.Zero(value)
+		is.ErrorIs(err, errFoo)
+		release1()
+
+		f3, release3 := delayed(3, nil)
+		f4, release4 := delayed(0, errFoo)
+		release3()
+
+		value, err = FutureRace(f3, f4).Await(context.Background())
+		is.Equal(3, value)
+		is.NoError(err)
+		release4()
+
+		_, err = FutureRace[int]().Await(context.Background())
+		is.ErrorIs(err, ErrNoFutures)
+	})
+}
+
+func TestSemaphore(t *testing.T) { //nolint:paralleltest
+	// t.Parallel()
+	testWithTimeout(t, time.Second)
+
+	t.Run("weights", func(t *testing.T) { //nolint:paralleltest
+		// t.Parallel()
+		is := assert.New(t)
+
+		s := NewSemaphore(3)
+
+		is.NoError(s.Acquire(context.Background(), 2))
+		is.True(s.TryAcquire(1))
+		is.False(s.TryAcquire(1))
+
+		s.Release(2)
+		is.True(s.TryAcquire(2))
+		is.False(s.TryAcquire(1))
+
+		s.Release(3)
+		is.True(s.TryAcquire(3))
+		s.Release(3)
+
+		is.PanicsWithValue("lo.Semaphore: released more than held", func() {
+			s.Release(1)
+		})
+	})
+
+	t.Run("waiters are served in order", func(t *testing.T) { //nolint:paralleltest
+		// t.Parallel()
+		is := assert.New(t)
+
+		s := NewSemaphore(2)
+		is.NoError(s.Acquire(context.Background(), 2))
+
+		acquired := make(chan int, 2)
+		go func() {
+			is.NoError(s.Acquire(context.Background(), 2))
+			acquired <- 2
+		}()
+
+		is.Eventually(func() bool {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			return len(s.waiters) == 1
+		}, time.Second, time.Millisecond)
+
+		// a smaller request does not overtake the waiting one
+		is.False(s.TryAcquire(1))
+
+		s.Release(1)
+		select {
+		case <-acquired:
+			is.Fail("acquired too early")
+		default:
+		}
+
+		s.Release(1)
+		is.Equal(2, <-acquired)
+		s.Release(2)
+	})
+
+	t.Run("acquire with canceled context", func(t *testing.T) { //nolint:paralleltest
+		// t.Parallel()
+		is := assert.New(t)
+
+		s := NewSemaphore(1)
+		is.NoError(s.Acquire(context.Background(), 1))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error)
+		go func() {
+			done <- s.Acquire(ctx, 1)
+		}()
+
+		is.Eventually(func() bool {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			return len(s.waiters) == 1
+		}, time.Second, time.Millisecond)
+
+		cancel()
+		is.ErrorIs(<-done, context.Canceled)
+
+		s.mu.Lock()
+		is.Empty(s.waiters)
+		s.mu.Unlock()
+
+		s.Release(1)
+		is.True(s.TryAcquire(1))
+
+		// a weight larger than the semaphore is never acquired
+		ctx, cancel = context.WithCancel(context.Background())
+		cancel()
+		is.ErrorIs(s.Acquire(ctx, 2), context.Canceled)
+	})
+}
+
+func TestWorkerPool(t *testing.T) { //nolint:paralleltest
+	// t.Parallel()
+	testWithTimeout(t, time.Second)
+
+	t.Run("results", func(t *testing.T) { //nolint:paralleltest
+		// t.Parallel()
+		is := assert.New(t)
+
+		pool := NewWorkerPool[int](WorkerPoolOptions{Workers: 3, QueueSize: 10})
+
+		var running, maxRunning int32
+		for i := 0; i < 10; i++ {
+			i := i
+			is.NoError(pool.Submit(func() (int, error) {
+				n := atomic.AddInt32(&running, 1)
+				for {
+					m := atomic.LoadInt32(&maxRunning)
+					if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+						break
+					}
+				}
+				defer atomic.AddInt32(&running, -1)
+
+				if i == 5 {
+					return 0, assert.AnError
+				}
+				return i * 2, nil
+			}))
+		}
+
+		go func() {
+			is.NoError(pool.Shutdown(context.Background()))
+		}()
+
+		values := []int{}
+		errs := 0
+		for result := range pool.Results() {
+			if result.B != nil {
+				is.ErrorIs(result.B, assert.AnError)
+				errs++
+				continue
+			}
+			values = append(values, result.A)
+		}
+
+		sort.Ints(values)
+		is.Equal([]int{0, 2, 4, 6, 8, 12, 14, 16, 18}, values)
+		is.Equal(1, errs)
+		is.LessOrEqual(maxRunning, int32(3))
+
+		is.ErrorIs(pool.Submit(func() (int, error) { return 0, nil }), ErrPoolShutdown)
+	})
+
+	t.Run("queue policies", func(t *testing.T) { //nolint:paralleltest
+		// t.Parallel()
+		is := assert.New(t)
+
+		for _, policy := range []QueuePolicy{QueueDrop, QueueError} {
+			pool := NewWorkerPool[int](WorkerPoolOptions{Workers: 1, QueueSize: 1, QueuePolicy: policy})
+
+			release := make(chan struct{})
+			started := make(chan struct{})
+			blocking := func() (int, error) {
+				close(started)
+				<-release
+				return 1, nil
+			}
+
+			is.NoError(pool.Submit(blocking))
+			<-started
+			is.NoError(pool.Submit(func() (int, error) { return 2, nil }))
+
+			err := pool.Submit(func() (int, error) { return 3, nil })
+			if policy == QueueDrop {
+				is.NoError(err)
+				is.Equal(1, pool.Dropped())
+			} else {
+				is.ErrorIs(err, ErrQueueFull)
+				is.Equal(0, pool.Dropped())
+			}
+
+			close(release)
+			go func() {
+				is.NoError(pool.Shutdown(context.Background()))
+			}()
+
+			is.Equal([]Tuple2[int, error]{{A: 1}, {A: 2}}, ChannelToSlice(pool.Results()))
+		}
+	})
+
+	t.Run("blocked submissions", func(t *testing.T) { //nolint:paralleltest
+		// t.Parallel()
+		is := assert.New(t)
+
+		pool := NewWorkerPool[int](WorkerPoolOptions{Workers: 1})
+
+		release := make(chan struct{})
+		is.NoError(pool.Submit(func() (int, error) {
+			<-release
+			return 1, nil
+		}))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		is.ErrorIs(pool.SubmitWithContext(ctx, func() (int, error) { return 2, nil }), context.Canceled)
+
+		submitted := make(chan error)
+		go func() {
+			submitted <- pool.Submit(func() (int, error) { return 3, nil })
+		}()
+
+		// shutting down unblocks the pending submission
+		shutdownCtx, cancelShutdown := context.WithCancel(context.Background())
+		cancelShutdown()
+		is.ErrorIs(pool.Shutdown(shutdownCtx), context.Canceled)
+		is.ErrorIs(<-submitted, ErrPoolShutdown)
+
+		close(release)
+		is.Equal([]Tuple2[int, error]{{A: 1}}, ChannelToSlice(pool.Results()))
+		is.NoError(pool.Shutdown(context.Background()))
+	})
+
+	t.Run("panics are returned as errors", func(t *testing.T) { //nolint:paralleltest
+		// t.Parallel()
+		is := assert.New(t)
+
+		pool := NewWorkerPool[int](WorkerPoolOptions{QueueSize: 1})
+		is.NoError(pool.Submit(func() (int, error) {
+			panic("boom")
+		}))
+
+		go func() {
+			is.NoError(pool.Shutdown(context.Background()))
+		}()
+
+		results := ChannelToSlice(pool.Results())
+		is.Len(results, 1)
+		is.EqualError(results[0].B, "lo.WorkerPool: recovered from panic: boom")
+	})
+}
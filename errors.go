@@ -346,6 +346,16 @@ func TryCatchWithErrorValue(callback func() error, catch func(any)) {
 	}
 }
 
+// recoverToError turns a value recovered from a panic into an error, prefixed by the name of the helper.
+// The recovered error, if any, is wrapped.
+func recoverToError(name string, r any) error {
+	if e, ok := r.(error); ok {
+		return fmt.Errorf("%s: recovered from panic: %w", name, e)
+	}
+
+	return fmt.Errorf("%s: recovered from panic: %v", name, r)
+}
+
 // ErrorsAs is a shortcut for errors.As(err, &&T).
 // Play: https://go.dev/play/p/8wk5rH8UfrE
 func ErrorsAs[T error](err error) (T, bool) {
@@ -1,6 +1,7 @@
 package lo
 
 import (
+	"sort"
 	"time"
 
 	"github.com/samber/lo/internal/constraints"
@@ -131,6 +132,54 @@ func FindLastIndexOf[T any](collection []T, predicate func(item T) bool) (T, int
 	return result, -1, false
 }
 
+// BinarySearch searches a value in a collection sorted in ascending order.
+// Returns the index of the first occurrence of the value and true when found, or the index
+// at which the value would be inserted and false otherwise. The complexity is O(log n).
+func BinarySearch[T constraints.Ordered](collection []T, target T) (int, bool) {
+	i := LowerBound(collection, target)
+	return i, i < len(collection) && collection[i] == target
+}
+
+// BinarySearchBy searches a key in a collection sorted in ascending order by iteratee (see IsSortedBy).
+// Returns the index of the first item having this key and true when found, or the index
+// at which such an item would be inserted and false otherwise. The complexity is O(log n).
+func BinarySearchBy[T any, K constraints.Ordered](collection []T, target K, iteratee func(item T) K) (int, bool) {
+	i := LowerBoundBy(collection, target, iteratee)
+	return i, i < len(collection) && iteratee(collection[i]) == target
+}
+
+// LowerBound returns the index of the first value greater than or equal to target
+// in a collection sorted in ascending order, or the collection length if there is none.
+func LowerBound[T constraints.Ordered](collection []T, target T) int {
+	return sort.Search(len(collection), func(i int) bool {
+		return collection[i] >= target
+	})
+}
+
+// LowerBoundBy returns the index of the first item whose key is greater than or equal to target
+// in a collection sorted in ascending order by iteratee, or the collection length if there is none.
+func LowerBoundBy[T any, K constraints.Ordered](collection []T, target K, iteratee func(item T) K) int {
+	return sort.Search(len(collection), func(i int) bool {
+		return iteratee(collection[i]) >= target
+	})
+}
+
+// UpperBound returns the index of the first value strictly greater than target
+// in a collection sorted in ascending order, or the collection length if there is none.
+func UpperBound[T constraints.Ordered](collection []T, target T) int {
+	return sort.Search(len(collection), func(i int) bool {
+		return collection[i] > target
+	})
+}
+
+// UpperBoundBy returns the index of the first item whose key is strictly greater than target
+// in a collection sorted in ascending order by iteratee, or the collection length if there is none.
+func UpperBoundBy[T any, K constraints.Ordered](collection []T, target K, iteratee func(item T) K) int {
+	return sort.Search(len(collection), func(i int) bool {
+		return iteratee(collection[i]) > target
+	})
+}
+
 // FindOrElse searches for an element in a slice based on a predicate. Returns the element if found or a given fallback value otherwise.
 // Play: https://go.dev/play/p/Eo7W0lvKTky
 func FindOrElse[T any](collection []T, fallback T, predicate func(item T) bool) T {
@@ -915,6 +964,84 @@ func MaxIndexByErr[T any](collection []T, greater func(a, b T) (bool, error)) (T
 	return mAx, index, nil
 }
 
+// TopK returns the k largest values of a collection, sorted in descending order.
+// It uses a bounded heap: the complexity is O(n log k) and the collection is not sorted.
+// Returns all the values when k is greater than the collection length.
+func TopK[T constraints.Ordered](collection []T, k int) []T {
+	return topKBy(collection, k, func(a, b T) bool {
+		return a > b
+	})
+}
+
+// TopKBy returns the k largest values of a collection using the given comparison function,
+// sorted in descending order. Equal values keep their original order.
+// It uses a bounded heap: the complexity is O(n log k) and the collection is not sorted.
+// Returns all the values when k is greater than the collection length.
+func TopKBy[T any](collection []T, k int, greater func(a, b T) bool) []T {
+	return topKBy(collection, k, greater)
+}
+
+// BottomK returns the k smallest values of a collection, sorted in ascending order.
+// It uses a bounded heap: the complexity is O(n log k) and the collection is not sorted.
+// Returns all the values when k is greater than the collection length.
+func BottomK[T constraints.Ordered](collection []T, k int) []T {
+	return topKBy(collection, k, func(a, b T) bool {
+		return a < b
+	})
+}
+
+// BottomKBy returns the k smallest values of a collection using the given comparison function,
+// sorted in ascending order. Equal values keep their original order.
+// It uses a bounded heap: the complexity is O(n log k) and the collection is not sorted.
+// Returns all the values when k is greater than the collection length.
+func BottomKBy[T any](collection []T, k int, less func(a, b T) bool) []T {
+	return topKBy(collection, k, less)
+}
+
+type rankedItem[T any] struct {
+	item  T
+	index int
+}
+
+// topKBy keeps the k best items in a heap whose root is the worst kept item.
+// On ties, the earliest item is considered better.
+func topKBy[T any](collection []T, k int, better func(a, b T) bool) []T {
+	if k > len(collection) {
+		k = len(collection)
+	}
+	if k <= 0 {
+		return []T{}
+	}
+
+	worst := NewPriorityQueue(func(a, b rankedItem[T]) bool {
+		if better(b.item, a.item) {
+			return true
+		}
+		if better(a.item, b.item) {
+			return false
+		}
+		return a.index > b.index
+	})
+
+	for i := range collection {
+		item := rankedItem[T]{item: collection[i], index: i}
+
+		if worst.Len() < k {
+			worst.Push(item)
+		} else {
+			worst.PushPop(item)
+		}
+	}
+
+	result := make([]T, worst.Len())
+	for i := len(result) - 1; i >= 0; i-- {
+		item, _ := worst.Pop()
+		result[i] = item.item
+	}
+
+	return result
+}
+
 // Latest searches the maximum time.Time of a collection.
 // Returns zero value when the collection is empty.
 // Play: https://go.dev/play/p/dBfdf5s8s-Y
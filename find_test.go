@@ -3,6 +3,7 @@ package lo
 import (
 	"errors"
 	"math/rand"
+	"sort"
 	"testing"
 	"time"
 
@@ -2482,3 +2483,143 @@ func TestSamplesBy_sparseBoundary(t *testing.T) {
 		is.Len(Uniq(result), count)
 	}
 }
+
+func TestTopK(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	is.Equal([]int{9, 8, 7}, TopK([]int{3, 9, 1, 7, 8, 2}, 3))
+	is.Equal([]int{3, 2, 1}, TopK([]int{1, 3, 2}, 10))
+	is.Equal([]int{5, 5}, TopK([]int{5, 1, 5, 5}, 2))
+	is.Empty(TopK([]int{1, 2}, 0))
+	is.Empty(TopK([]int{1, 2}, -1))
+	is.Empty(TopK([]int{}, 3))
+
+	input := make([]int, 1000)
+	for i := range input {
+		input[i] = (i * 7919) % 1009
+	}
+	expected := append([]int{}, input...)
+	sort.Sort(sort.Reverse(sort.IntSlice(expected)))
+	is.Equal(expected[:25], TopK(input, 25))
+}
+
+func TestTopKBy(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	type user struct {
+		name  string
+		score int
+	}
+
+	users := []user{{"a", 10}, {"b", 30}, {"c", 20}, {"d", 30}, {"e", 20}}
+	greater := func(a, b user) bool {
+		return a.score > b.score
+	}
+
+	is.Equal([]user{{"b", 30}, {"d", 30}, {"c", 20}}, TopKBy(users, 3, greater))
+	is.Equal([]user{{"b", 30}}, TopKBy(users, 1, greater))
+	is.Empty(TopKBy([]user{}, 1, greater))
+}
+
+func TestBottomK(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	is.Equal([]int{1, 2, 3}, BottomK([]int{3, 9, 1, 7, 8, 2}, 3))
+	is.Equal([]string{"a", "b"}, BottomK([]string{"c", "b", "a"}, 2))
+	is.Empty(BottomK([]int{}, 3))
+}
+
+func TestBottomKBy(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	users := []foo{{"ccc"}, {"a"}, {"bb"}, {"d"}}
+	less := func(a, b foo) bool {
+		return len(a.bar) < len(b.bar)
+	}
+
+	is.Equal([]foo{{"a"}, {"d"}, {"bb"}}, BottomKBy(users, 3, less))
+}
+
+func TestBinarySearch(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	collection := []int{1, 3, 3, 3, 5, 8}
+
+	index, ok := BinarySearch(collection, 3)
+	is.Equal(1, index)
+	is.True(ok)
+
+	index, ok = BinarySearch(collection, 8)
+	is.Equal(5, index)
+	is.True(ok)
+
+	index, ok = BinarySearch(collection, 4)
+	is.Equal(4, index)
+	is.False(ok)
+
+	index, ok = BinarySearch(collection, 0)
+	is.Zero(index)
+	is.False(ok)
+
+	index, ok = BinarySearch(collection, 42)
+	is.Equal(6, index)
+	is.False(ok)
+
+	index, ok = BinarySearch([]int{}, 1)
+	is.Zero(index)
+	is.False(ok)
+}
+
+func TestBinarySearchBy(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	type event struct {
+		name string
+		ts   int
+	}
+
+	events := []event{{"a", 10}, {"b", 20}, {"c", 20}, {"d", 40}}
+	ts := func(e event) int {
+		return e.ts
+	}
+
+	index, ok := BinarySearchBy(events, 20, ts)
+	is.Equal(1, index)
+	is.True(ok)
+
+	index, ok = BinarySearchBy(events, 30, ts)
+	is.Equal(3, index)
+	is.False(ok)
+}
+
+func TestLowerUpperBound(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	collection := []int{1, 3, 3, 3, 5, 8}
+
+	is.Equal(1, LowerBound(collection, 3))
+	is.Equal(4, UpperBound(collection, 3))
+	is.Equal(4, LowerBound(collection, 4))
+	is.Equal(4, UpperBound(collection, 4))
+	is.Zero(LowerBound(collection, -1))
+	is.Equal(6, UpperBound(collection, 8))
+	is.Zero(LowerBound([]int{}, 1))
+	is.Zero(UpperBound([]int{}, 1))
+
+	words := []string{"a", "bb", "cc", "dddd"}
+	length := func(s string) int {
+		return len(s)
+	}
+
+	is.Equal(1, LowerBoundBy(words, 2, length))
+	is.Equal(3, UpperBoundBy(words, 2, length))
+	is.Equal(3, LowerBoundBy(words, 3, length))
+	is.Equal(4, UpperBoundBy(words, 4, length))
+}
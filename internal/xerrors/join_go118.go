@@ -0,0 +1,52 @@
+//go:build !go1.20
+
+package xerrors
+
+import "strings"
+
+// Join returns an error that wraps the given errors. Any nil error values are discarded.
+// Join returns nil if every value in errs is nil.
+// The error formats as the concatenation of the strings obtained by calling the Error
+// method of each element of errs, with a newline between each string.
+func Join(errs ...error) error {
+	n := 0
+	for _, err := range errs {
+		if err != nil {
+			n++
+		}
+	}
+
+	if n == 0 {
+		return nil
+	}
+
+	e := &joinError{
+		errs: make([]error, 0, n),
+	}
+
+	for _, err := range errs {
+		if err != nil {
+			e.errs = append(e.errs, err)
+		}
+	}
+
+	return e
+}
+
+type joinError struct {
+	errs []error
+}
+
+func (e *joinError) Error() string {
+	msgs := make([]string, 0, len(e.errs))
+	for _, err := range e.errs {
+		msgs = append(msgs, err.Error())
+	}
+
+	return strings.Join(msgs, "\n")
+}
+
+// Unwrap is recognized by errors.Is and errors.As starting from Go 1.20.
+func (e *joinError) Unwrap() []error {
+	return e.errs
+}
@@ -23,3 +23,11 @@ func Int64() int64 {
 	// bearer:disable go_gosec_crypto_weak_random
 	return rand.Int63()
 }
+
+// Int64N returns, as an int64, a pseudo-random number in the half-open interval [0,n)
+// from the default Source.
+// It panics if n <= 0.
+func Int64N(n int64) int64 {
+	// bearer:disable go_gosec_crypto_weak_random
+	return rand.Int63n(n)
+}
@@ -21,3 +21,10 @@ func IntN(n int) int {
 func Int64() int64 {
 	return rand.Int64()
 }
+
+// Int64N returns, as an int64, a pseudo-random number in the half-open interval [0,n)
+// from the default Source.
+// It panics if n <= 0.
+func Int64N(n int64) int64 {
+	return rand.Int64N(n)
+}
@@ -2,6 +2,8 @@
 package xtime
 
 import (
+	"context"
+	"sync"
 	"time"
 )
 
@@ -18,23 +20,39 @@ func NewFakeClockAt(t time.Time) *FakeClock {
 type FakeClock struct {
 	_ noCopy
 
-	// Not protected by a mutex. If a warning is thrown in your tests,
-	// just disable parallel tests.
+	// Sleeping advances the time of every goroutine sharing the clock. If a test
+	// depends on the time elapsed, disable parallel tests or use a dedicated clock.
+	mu   sync.Mutex
 	time time.Time
 }
 
 func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	return c.time
 }
 
 func (c *FakeClock) Since(t time.Time) time.Duration {
-	return c.time.Sub(t)
+	return c.Now().Sub(t)
 }
 
 func (c *FakeClock) Until(t time.Time) time.Duration {
-	return t.Sub(c.time)
+	return t.Sub(c.Now())
 }
 
 func (c *FakeClock) Sleep(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	c.time = c.time.Add(d)
 }
+
+func (c *FakeClock) SleepWithContext(ctx context.Context, d time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	c.Sleep(d)
+	return nil
+}
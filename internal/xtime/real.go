@@ -2,6 +2,7 @@
 package xtime
 
 import (
+	"context"
 	"time"
 )
 
@@ -28,3 +29,19 @@ func (c *RealClock) Until(t time.Time) time.Duration {
 func (c *RealClock) Sleep(d time.Duration) {
 	time.Sleep(d)
 }
+
+func (c *RealClock) SleepWithContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
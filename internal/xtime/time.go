@@ -1,7 +1,10 @@
 //nolint:revive
 package xtime
 
-import "time"
+import (
+	"context"
+	"time"
+)
 
 var clock Clock = &RealClock{}
 
@@ -25,9 +28,14 @@ func Sleep(d time.Duration) {
 	clock.Sleep(d)
 }
 
+func SleepWithContext(ctx context.Context, d time.Duration) error {
+	return clock.SleepWithContext(ctx, d)
+}
+
 type Clock interface {
 	Now() time.Time
 	Since(t time.Time) time.Duration
 	Until(t time.Time) time.Duration
 	Sleep(d time.Duration)
+	SleepWithContext(ctx context.Context, d time.Duration) error
 }
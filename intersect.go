@@ -1,5 +1,7 @@
 package lo
 
+import "github.com/samber/lo/internal/constraints"
+
 // Contains returns true if an element is present in a collection.
 // Play: https://go.dev/play/p/W1EvyqY6t9j
 func Contains[T comparable](collection []T, element T) bool {
@@ -614,3 +616,128 @@ func ElementsMatchBy[T any, K comparable](list1, list2 []T, iteratee func(item T
 
 	return true
 }
+
+// IntersectSorted returns the distinct values present in every collection.
+// Collections must be sorted in ascending order. Unlike Intersect, no map is allocated:
+// the collections are walked side by side in O(n) and the result is sorted.
+func IntersectSorted[T constraints.Ordered, Slice ~[]T](lists ...Slice) Slice {
+	return IntersectSortedBy(func(item T) T { return item }, lists...)
+}
+
+// IntersectSortedBy returns the items of the first collection whose key is present in every collection,
+// keeping a single item per key. Collections must be sorted in ascending order by iteratee.
+// The collections are walked side by side in O(n) and the result is sorted.
+func IntersectSortedBy[T any, K constraints.Ordered, Slice ~[]T](iteratee func(item T) K, lists ...Slice) Slice {
+	result := Slice{}
+	if len(lists) == 0 {
+		return result
+	}
+
+	first := lists[0]
+	cursors := make([]int, len(lists))
+
+	for cursors[0] < len(first) {
+		target := iteratee(first[cursors[0]])
+		matched := true
+
+		for i := 1; i < len(lists); i++ {
+			for cursors[i] < len(lists[i]) && iteratee(lists[i][cursors[i]]) < target {
+				cursors[i]++
+			}
+
+			if cursors[i] >= len(lists[i]) {
+				return result
+			}
+
+			if key := iteratee(lists[i][cursors[i]]); target < key {
+				for cursors[0] < len(first) && iteratee(first[cursors[0]]) < key {
+					cursors[0]++
+				}
+				matched = false
+				break
+			}
+		}
+
+		if matched {
+			result = append(result, first[cursors[0]])
+			cursors[0] = skipSortedKey(first, cursors[0], target, iteratee)
+		}
+	}
+
+	return result
+}
+
+// UnionSorted returns the distinct values of all collections.
+// Collections must be sorted in ascending order. Unlike Union, no map is allocated
+// and the result is sorted. The complexity is O(n log k) for k collections.
+func UnionSorted[T constraints.Ordered, Slice ~[]T](lists ...Slice) Slice {
+	return UnionSortedBy(func(item T) T { return item }, lists...)
+}
+
+// UnionSortedBy returns the items of all collections, keeping the first item found for each key.
+// Collections must be sorted in ascending order by iteratee, and the result is sorted.
+// The complexity is O(n log k) for k collections.
+func UnionSortedBy[T any, K constraints.Ordered, Slice ~[]T](iteratee func(item T) K, lists ...Slice) Slice {
+	result := Slice{}
+
+	var last K
+	forEachSortedBy(iteratee, lists, func(item T, key K) {
+		if len(result) == 0 || key != last {
+			result = append(result, item)
+			last = key
+		}
+	})
+
+	return result
+}
+
+// DifferenceSorted returns the difference between two collections sorted in ascending order.
+// The first value is the collection of elements absent from list2.
+// The second value is the collection of elements absent from list1.
+// Unlike Difference, no map is allocated: the collections are walked side by side in O(n).
+func DifferenceSorted[T constraints.Ordered, Slice ~[]T](list1, list2 Slice) (Slice, Slice) {
+	return DifferenceSortedBy(list1, list2, func(item T) T { return item })
+}
+
+// DifferenceSortedBy returns the difference between two collections sorted in ascending order by iteratee.
+// The first value is the collection of items whose key is absent from list2.
+// The second value is the collection of items whose key is absent from list1.
+// The collections are walked side by side in O(n).
+func DifferenceSortedBy[T any, K constraints.Ordered, Slice ~[]T](list1, list2 Slice, iteratee func(item T) K) (Slice, Slice) {
+	left := make(Slice, 0, len(list1))
+	right := make(Slice, 0, len(list2))
+
+	i, j := 0, 0
+	for i < len(list1) && j < len(list2) {
+		key1 := iteratee(list1[i])
+		key2 := iteratee(list2[j])
+
+		switch {
+		case key1 < key2:
+			left = append(left, list1[i])
+			i++
+		case key2 < key1:
+			right = append(right, list2[j])
+			j++
+		default:
+			i = skipSortedKey(list1, i, key1, iteratee)
+			j = skipSortedKey(list2, j, key1, iteratee)
+		}
+	}
+
+	left = append(left, list1[i:]...)
+	right = append(right, list2[j:]...)
+
+	return left, right
+}
+
+// skipSortedKey returns the index of the first item after `index` whose key is different from `key`.
+// It always moves forward, even for keys that are not equal to themselves (ie: NaN).
+func skipSortedKey[T any, K constraints.Ordered, Slice ~[]T](collection Slice, index int, key K, iteratee func(item T) K) int {
+	index++
+	for index < len(collection) && iteratee(collection[index]) == key {
+		index++
+	}
+
+	return index
+}
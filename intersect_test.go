@@ -2,6 +2,7 @@ package lo
 
 import (
 	"errors"
+	"math"
 	"strconv"
 	"testing"
 
@@ -1061,3 +1062,135 @@ func TestElementsMatchBy(t *testing.T) {
 		func(item someType) string { return item.key },
 	))
 }
+
+func TestIntersectSorted(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	is.Equal([]int{2, 5}, IntersectSorted([]int{1, 2, 2, 4, 5, 7}, []int{2, 3, 5, 7}, []int{0, 2, 2, 5, 6}))
+	is.Equal([]int{1, 2}, IntersectSorted([]int{1, 1, 2}))
+	is.Empty(IntersectSorted([]int{1, 2}, []int{3, 4}))
+	is.Empty(IntersectSorted([]int{1, 2}, []int{}))
+	is.Empty(IntersectSorted[int, []int]())
+
+	type myStrings []string
+	allStrings := myStrings{"a", "b"}
+	nonempty := IntersectSorted(allStrings, myStrings{"b"})
+	is.IsType(nonempty, allStrings, "type preserved")
+}
+
+func TestIntersectSortedBy(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	type sample struct {
+		source string
+		ts     int
+	}
+
+	ts := func(s sample) int {
+		return s.ts
+	}
+
+	result := IntersectSortedBy(
+		ts,
+		[]sample{{"a", 1}, {"a", 2}, {"a", 2}, {"a", 4}},
+		[]sample{{"b", 2}, {"b", 3}, {"b", 4}},
+	)
+	is.Equal([]sample{{"a", 2}, {"a", 4}}, result)
+}
+
+func TestUnionSorted(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	is.Equal([]int{0, 1, 2, 3, 4, 5}, UnionSorted([]int{1, 3, 3, 5}, []int{0, 1, 2}, []int{4}))
+	is.Equal([]int{1, 2}, UnionSorted([]int{1, 1, 2}))
+	is.Empty(UnionSorted[int, []int]())
+
+	type myStrings []string
+	allStrings := myStrings{"a", "c"}
+	nonempty := UnionSorted(allStrings, myStrings{"b"})
+	is.IsType(nonempty, allStrings, "type preserved")
+}
+
+func TestUnionSortedBy(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	type sample struct {
+		source string
+		ts     int
+	}
+
+	ts := func(s sample) int {
+		return s.ts
+	}
+
+	result := UnionSortedBy(
+		ts,
+		[]sample{{"a", 1}, {"a", 3}},
+		[]sample{{"b", 1}, {"b", 2}, {"b", 3}},
+	)
+	is.Equal([]sample{{"a", 1}, {"b", 2}, {"a", 3}}, result)
+}
+
+func TestDifferenceSorted(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	left, right := DifferenceSorted([]int{0, 1, 2, 2, 3, 4, 5}, []int{0, 2, 6, 6})
+	is.Equal([]int{1, 3, 4, 5}, left)
+	is.Equal([]int{6, 6}, right)
+
+	left, right = DifferenceSorted([]int{1, 2}, []int{1, 2})
+	is.Empty(left)
+	is.Empty(right)
+
+	left, right = DifferenceSorted([]int{}, []int{1})
+	is.Empty(left)
+	is.Equal([]int{1}, right)
+
+	type myStrings []string
+	allStrings := myStrings{"a", "c"}
+	a, b := DifferenceSorted(allStrings, myStrings{"b"})
+	is.IsType(a, allStrings, "type preserved")
+	is.IsType(b, allStrings, "type preserved")
+}
+
+func TestDifferenceSortedBy(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	type sample struct {
+		source string
+		ts     int
+	}
+
+	ts := func(s sample) int {
+		return s.ts
+	}
+
+	left, right := DifferenceSortedBy(
+		[]sample{{"a", 1}, {"a", 2}, {"a", 4}},
+		[]sample{{"b", 2}, {"b", 3}},
+		ts,
+	)
+	is.Equal([]sample{{"a", 1}, {"a", 4}}, left)
+	is.Equal([]sample{{"b", 3}}, right)
+}
+
+func TestSortedHelpersWithNaN(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	nan := math.NaN()
+
+	// NaN is not equal to itself: the helpers must still terminate
+	result := IntersectSorted([]float64{nan, 1, 2}, []float64{nan, 2})
+	is.Contains(result, 2.0)
+
+	left, right := DifferenceSorted([]float64{nan, 1}, []float64{nan, 2})
+	is.Contains(left, 1.0)
+	is.Contains(right, 2.0)
+}
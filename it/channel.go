@@ -3,7 +3,9 @@
 package it
 
 import (
+	"context"
 	"iter"
+	"time"
 
 	"github.com/samber/lo"
 )
@@ -51,3 +53,79 @@ func ChannelToSeq[T any](ch <-chan T) iter.Seq[T] {
 		}
 	}
 }
+
+// Batch returns a sequence of batches of at most maxSize messages received from a channel. A batch is
+// yielded when it is full, when maxWait elapsed since its first message, and when the channel is closed.
+// A maxWait lower than or equal to 0 disables the time limit.
+func Batch[T any](ch <-chan T, maxSize int, maxWait time.Duration) iter.Seq[[]T] {
+	return BatchWithContext(context.Background(), ch, maxSize, maxWait)
+}
+
+// BatchWithContext is like Batch, but stops when the context is canceled and drops the pending batch.
+func BatchWithContext[T any](ctx context.Context, ch <-chan T, maxSize int, maxWait time.Duration) iter.Seq[[]T] {
+	if maxSize <= 0 {
+		panic("it.Batch: maxSize must be greater than 0")
+	}
+
+	return func(yield func([]T) bool) {
+		var batch []T
+		var timer *time.Timer
+		var timeout <-chan time.Time
+
+		stopTimer := func() {
+			if timer != nil {
+				timer.Stop()
+				timer, timeout = nil, nil
+			}
+		}
+		defer stopTimer()
+
+		flush := func() bool {
+			stopTimer()
+
+			if len(batch) == 0 {
+				return true
+			}
+
+			current := batch
+			batch = nil
+
+			return yield(current)
+		}
+
+		for {
+			select {
+			case item, ok := <-ch:
+				if !ok {
+					flush()
+					return
+				}
+
+				if batch == nil {
+					batch = make([]T, 0, maxSize)
+
+					if maxWait > 0 {
+						timer = time.NewTimer(maxWait)
+						timeout = timer.C
+					}
+				}
+
+				batch = append(batch, item)
+
+				if len(batch) >= maxSize && !flush() {
+					return
+				}
+
+			case <-timeout:
+				timer, timeout = nil, nil
+
+				if !flush() {
+					return
+				}
+
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
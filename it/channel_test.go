@@ -3,9 +3,11 @@
 package it
 
 import (
+	"context"
 	"maps"
 	"slices"
 	"testing"
+	"time"
 
 	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
@@ -58,3 +60,67 @@ func TestChannelToSeq(t *testing.T) {
 
 	is.Equal([]int{1, 2, 3}, slices.Collect(items))
 }
+
+func TestBatch(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	is.Equal([][]int{{1, 2}, {3, 4}, {5}}, slices.Collect(Batch(lo.SliceToChannel(0, []int{1, 2, 3, 4, 5}), 2, time.Hour)))
+	is.Equal([][]int{{1, 2, 3}}, slices.Collect(Batch(lo.SliceToChannel(0, []int{1, 2, 3}), 10, 0)))
+
+	ch := make(chan int)
+	close(ch)
+	is.Empty(slices.Collect(Batch(ch, 2, time.Hour)))
+
+	// breaking the iteration does not consume the remaining messages
+	ch2 := lo.SliceToChannel(5, []int{1, 2, 3, 4, 5})
+	for batch := range Batch(ch2, 2, time.Hour) {
+		is.Equal([]int{1, 2}, batch)
+		break
+	}
+	is.Equal([]int{3, 4, 5}, lo.ChannelToSlice(ch2))
+
+	is.PanicsWithValue("it.Batch: maxSize must be greater than 0", func() {
+		Batch(ch, 0, time.Hour)
+	})
+}
+
+func TestBatchMaxWait(t *testing.T) { //nolint:paralleltest
+	// t.Parallel()
+	is := assert.New(t)
+
+	ch := make(chan int, 3)
+	ch <- 1
+	ch <- 2
+	ch <- 3
+
+	start := time.Now()
+
+	for batch := range Batch(ch, 10, 20*time.Millisecond) {
+		is.Equal([]int{1, 2, 3}, batch)
+		is.GreaterOrEqual(time.Since(start), 20*time.Millisecond)
+		break
+	}
+}
+
+func TestBatchWithContext(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch := make(chan int, 3)
+	ch <- 1
+	ch <- 2
+	ch <- 3
+
+	batches := [][]int{}
+	for batch := range BatchWithContext(ctx, ch, 2, 0) {
+		batches = append(batches, batch)
+		cancel()
+	}
+
+	// the pending batch is dropped
+	is.Equal([][]int{{1, 2}}, batches)
+}
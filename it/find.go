@@ -363,6 +363,56 @@ func MaxIndexBy[T any](collection iter.Seq[T], comparison func(a, b T) bool) (T,
 	return mAx, index
 }
 
+// TopK returns the k largest values of a sequence, sorted in descending order.
+// It uses a bounded heap: the sequence is consumed once and at most 2k values are kept in memory.
+// Will iterate through the entire sequence.
+func TopK[T constraints.Ordered](collection iter.Seq[T], k int) []T {
+	return topKBy(collection, k, func(a, b T) bool {
+		return a > b
+	})
+}
+
+// TopKBy returns the k largest values of a sequence using the given comparison function,
+// sorted in descending order. Equal values keep their original order.
+// Will iterate through the entire sequence.
+func TopKBy[T any](collection iter.Seq[T], k int, greater func(a, b T) bool) []T {
+	return topKBy(collection, k, greater)
+}
+
+// BottomK returns the k smallest values of a sequence, sorted in ascending order.
+// It uses a bounded heap: the sequence is consumed once and at most 2k values are kept in memory.
+// Will iterate through the entire sequence.
+func BottomK[T constraints.Ordered](collection iter.Seq[T], k int) []T {
+	return topKBy(collection, k, func(a, b T) bool {
+		return a < b
+	})
+}
+
+// BottomKBy returns the k smallest values of a sequence using the given comparison function,
+// sorted in ascending order. Equal values keep their original order.
+// Will iterate through the entire sequence.
+func BottomKBy[T any](collection iter.Seq[T], k int, less func(a, b T) bool) []T {
+	return topKBy(collection, k, less)
+}
+
+// topKBy feeds lo.TopKBy with chunks of the sequence: the k best items kept so far come first,
+// followed by the next k items, so that ties keep their order and at most 2k items are kept in memory.
+func topKBy[T any](collection iter.Seq[T], k int, better func(a, b T) bool) []T {
+	if k <= 0 {
+		return []T{}
+	}
+
+	var buffer []T
+	for item := range collection {
+		buffer = append(buffer, item)
+		if len(buffer)-k == k {
+			buffer = lo.TopKBy(buffer, k, better)
+		}
+	}
+
+	return lo.TopKBy(buffer, k, better)
+}
+
 // Latest search the maximum time.Time of a collection.
 // Returns zero value when the collection is empty.
 // Will iterate through the entire sequence.
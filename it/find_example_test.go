@@ -387,6 +387,20 @@ func ExampleMaxIndexBy() {
 	// Output: Charlie 2
 }
 
+func ExampleTopK() {
+	result := TopK(slices.Values([]int{3, 9, 1, 7, 8, 2}), 3)
+
+	fmt.Printf("%v", result)
+	// Output: [9 8 7]
+}
+
+func ExampleBottomK() {
+	result := BottomK(slices.Values([]int{3, 9, 1, 7, 8, 2}), 3)
+
+	fmt.Printf("%v", result)
+	// Output: [1 2 3]
+}
+
 func ExampleLatest() {
 	now := time.Now()
 	past := now.Add(-time.Hour)
@@ -1239,3 +1239,54 @@ func TestSamplesBy(t *testing.T) {
 		is.IsType(nonempty, allStrings, "type preserved")
 	})
 }
+
+func TestTopK(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	is.Equal([]int{9, 8, 7}, TopK(values(3, 9, 1, 7, 8, 2), 3))
+	is.Equal([]int{3, 2, 1}, TopK(values(1, 3, 2), 10))
+	is.Empty(TopK(values(1, 2), 0))
+	is.Empty(TopK(values[int](), 3))
+}
+
+func TestTopKBy(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	type user struct {
+		name  string
+		score int
+	}
+
+	users := values(user{"a", 10}, user{"b", 30}, user{"c", 20}, user{"d", 30})
+	result := TopKBy(users, 3, func(a, b user) bool {
+		return a.score > b.score
+	})
+	is.Equal([]user{{"b", 30}, {"d", 30}, {"c", 20}}, result)
+
+	// longer than 2k, so that ties span several chunks
+	users = values(user{"a", 10}, user{"b", 30}, user{"c", 20}, user{"d", 30}, user{"e", 30}, user{"f", 40}, user{"g", 30}, user{"h", 30})
+	result = TopKBy(users, 3, func(a, b user) bool {
+		return a.score > b.score
+	})
+	is.Equal([]user{{"f", 40}, {"b", 30}, {"d", 30}}, result)
+}
+
+func TestBottomK(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	is.Equal([]int{1, 2, 3}, BottomK(values(3, 9, 1, 7, 8, 2), 3))
+	is.Empty(BottomK(values[int](), 3))
+}
+
+func TestBottomKBy(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	result := BottomKBy(values("ccc", "a", "bb", "d"), 3, func(a, b string) bool {
+		return len(a) < len(b)
+	})
+	is.Equal([]string{"a", "d", "bb"}, result)
+}
@@ -0,0 +1,216 @@
+//go:build go1.23
+
+package it
+
+import (
+	"iter"
+	"runtime"
+	"sync"
+)
+
+// ParallelOptions configures the parallel sequence helpers.
+type ParallelOptions struct {
+	// Concurrency is the number of workers calling the callback.
+	// When lower than 1, it defaults to runtime.GOMAXPROCS(0).
+	Concurrency int
+
+	// BufferSize is the maximum number of items read from the source sequence
+	// and not consumed yet. It provides backpressure when the consumer is slower
+	// than the workers. When lower than 1, it defaults to twice the concurrency.
+	BufferSize int
+
+	// Unordered yields results as soon as they are ready, instead of following
+	// the order of the source sequence.
+	Unordered bool
+}
+
+func (o ParallelOptions) normalize() (workers, bufferSize int) {
+	workers = o.Concurrency
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	bufferSize = o.BufferSize
+	if bufferSize < 1 {
+		bufferSize = 2 * workers
+	}
+
+	return workers, bufferSize
+}
+
+type parallelResult[R any] struct {
+	value    R
+	ok       bool
+	panicked bool
+	panicVal any
+}
+
+type parallelJob[T, R any] struct {
+	item T
+	slot chan parallelResult[R]
+}
+
+// ParallelMap manipulates a sequence and transforms it to a sequence of another type.
+// `transform` is called in parallel by `opts.Concurrency` workers, and at most `opts.BufferSize`
+// items are in flight. Results follow the order of the source sequence unless `opts.Unordered` is set.
+// A panic in `transform` is propagated to the consumer of the returned sequence.
+func ParallelMap[T, R any](collection iter.Seq[T], transform func(item T) R, opts ParallelOptions) iter.Seq[R] {
+	return ParallelFilterMap(collection, func(item T) (R, bool) {
+		return transform(item), true
+	}, opts)
+}
+
+// ParallelFilterMap returns a sequence obtained after both filtering and mapping using the given callback function.
+// `callback` is called in parallel by `opts.Concurrency` workers, and at most `opts.BufferSize`
+// items are in flight. Results follow the order of the source sequence unless `opts.Unordered` is set.
+// A panic in `callback` is propagated to the consumer of the returned sequence.
+func ParallelFilterMap[T, R any](collection iter.Seq[T], callback func(item T) (R, bool), opts ParallelOptions) iter.Seq[R] {
+	return func(yield func(R) bool) {
+		workers, bufferSize := opts.normalize()
+		ordered := !opts.Unordered
+
+		done := make(chan struct{})
+		tokens := make(chan struct{}, bufferSize)
+		jobs := make(chan parallelJob[T, R])
+		order := make(chan chan parallelResult[R], bufferSize)
+		results := make(chan parallelResult[R], bufferSize)
+
+		var wg sync.WaitGroup
+		var workersWg sync.WaitGroup
+		var source parallelResult[R]
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer close(order)
+			defer close(jobs)
+			defer func() {
+				if r := recover(); r != nil {
+					source = parallelResult[R]{panicked: true, panicVal: r}
+				}
+			}()
+
+			for item := range collection {
+				select {
+				case tokens <- struct{}{}:
+				case <-done:
+					return
+				}
+
+				var slot chan parallelResult[R]
+				if ordered {
+					// never blocks: less than `bufferSize` slots are pending
+					slot = make(chan parallelResult[R], 1)
+					order <- slot
+				}
+
+				select {
+				case jobs <- parallelJob[T, R]{item: item, slot: slot}:
+				case <-done:
+					return
+				}
+			}
+		}()
+
+		wg.Add(workers)
+		workersWg.Add(workers)
+		for w := 0; w < workers; w++ {
+			go func() {
+				defer wg.Done()
+				defer workersWg.Done()
+
+				for job := range jobs {
+					result := parallelCall(callback, job.item)
+
+					// never blocks: less than `bufferSize` results are pending
+					if ordered {
+						job.slot <- result
+					} else {
+						results <- result
+					}
+				}
+			}()
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			workersWg.Wait()
+			close(results)
+		}()
+
+		var stopOnce sync.Once
+		stop := func() {
+			stopOnce.Do(func() {
+				close(done)
+				wg.Wait()
+			})
+		}
+		// the goroutines are stopped even when the consumer panics
+		defer stop()
+
+		next := func() (parallelResult[R], bool) {
+			if !ordered {
+				result, ok := <-results
+				return result, ok
+			}
+
+			slot, ok := <-order
+			if !ok {
+				return parallelResult[R]{}, false
+			}
+			return <-slot, true
+		}
+
+		for {
+			result, ok := next()
+			if !ok {
+				break
+			}
+
+			<-tokens
+
+			if result.panicked {
+				stop()
+				panic(result.panicVal)
+			}
+
+			if result.ok && !yield(result.value) {
+				stop()
+				return
+			}
+		}
+
+		stop()
+
+		if source.panicked {
+			panic(source.panicVal)
+		}
+	}
+}
+
+// ParallelForEach iterates over elements of collection and invokes callback for each element.
+// `callback` is called in parallel by `opts.Concurrency` workers, and at most `opts.BufferSize`
+// items are in flight. Returns once every callback has been called. `opts.Unordered` is ignored.
+// A panic in `callback` is propagated to the caller.
+func ParallelForEach[T any](collection iter.Seq[T], callback func(item T), opts ParallelOptions) {
+	opts.Unordered = true
+
+	seq := ParallelFilterMap(collection, func(item T) (struct{}, bool) {
+		callback(item)
+		return struct{}{}, false
+	}, opts)
+
+	seq(func(struct{}) bool { return true })
+}
+
+func parallelCall[T, R any](callback func(item T) (R, bool), item T) (result parallelResult[R]) {
+	defer func() {
+		if r := recover(); r != nil {
+			result = parallelResult[R]{panicked: true, panicVal: r}
+		}
+	}()
+
+	value, ok := callback(item)
+	return parallelResult[R]{value: value, ok: ok}
+}
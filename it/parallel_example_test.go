@@ -0,0 +1,42 @@
+//go:build go1.23
+
+package it
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync/atomic"
+)
+
+func ExampleParallelMap() {
+	list := slices.Values([]string{"a", "b", "c", "d"})
+
+	result := ParallelMap(list, strings.ToUpper, ParallelOptions{Concurrency: 2})
+
+	fmt.Printf("%v", slices.Collect(result))
+	// Output: [A B C D]
+}
+
+func ExampleParallelFilterMap() {
+	list := slices.Values([]int{1, 2, 3, 4, 5, 6})
+
+	result := ParallelFilterMap(list, func(x int) (string, bool) {
+		return fmt.Sprintf("#%d", x), x%2 == 0
+	}, ParallelOptions{Concurrency: 2})
+
+	fmt.Printf("%v", slices.Collect(result))
+	// Output: [#2 #4 #6]
+}
+
+func ExampleParallelForEach() {
+	list := slices.Values([]int64{1, 2, 3, 4})
+
+	var sum int64
+	ParallelForEach(list, func(x int64) {
+		atomic.AddInt64(&sum, x)
+	}, ParallelOptions{Concurrency: 2})
+
+	fmt.Printf("%v", sum)
+	// Output: 10
+}
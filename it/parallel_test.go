@@ -0,0 +1,195 @@
+//go:build go1.23
+
+package it
+
+import (
+	"iter"
+	"slices"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/goleak"
+)
+
+func TestParallelMap(t *testing.T) { //nolint:paralleltest
+	// t.Parallel()
+
+	t.Run("keeps order", func(t *testing.T) { //nolint:paralleltest
+		// t.Parallel()
+		is := assert.New(t)
+
+		result := ParallelMap(RangeFrom(0, 1000), func(x int) string {
+			if x%7 == 0 {
+				time.Sleep(time.Millisecond)
+			}
+			return strconv.Itoa(x)
+		}, ParallelOptions{Concurrency: 4})
+
+		expected := make([]string, 1000)
+		for i := range expected {
+			expected[i] = strconv.Itoa(i)
+		}
+
+		is.Equal(expected, slices.Collect(result))
+	})
+
+	t.Run("unordered", func(t *testing.T) { //nolint:paralleltest
+		// t.Parallel()
+		is := assert.New(t)
+
+		result := slices.Collect(ParallelMap(RangeFrom(0, 100), func(x int) int {
+			return x * 2
+		}, ParallelOptions{Concurrency: 4, Unordered: true}))
+
+		sort.Ints(result)
+		is.Equal(slices.Collect(Map(RangeFrom(0, 100), func(x int) int { return x * 2 })), result)
+	})
+
+	t.Run("empty sequence", func(t *testing.T) { //nolint:paralleltest
+		// t.Parallel()
+		is := assert.New(t)
+
+		result := ParallelMap(values[int](), func(x int) int { return x }, ParallelOptions{})
+		is.Empty(slices.Collect(result))
+	})
+
+	t.Run("supports break", func(t *testing.T) { //nolint:paralleltest
+		// t.Parallel()
+
+		assertSeqSupportBreak(t, ParallelMap(RangeFrom(0, 100), func(x int) int { return x }, ParallelOptions{}))
+		assertSeqSupportBreak(t, ParallelMap(RangeFrom(0, 100), func(x int) int { return x }, ParallelOptions{Unordered: true}))
+	})
+}
+
+func TestParallelMapBackpressure(t *testing.T) { //nolint:paralleltest
+	// t.Parallel()
+	is := assert.New(t)
+
+	var read int64
+	source := func(yield func(int) bool) {
+		for i := 0; i < 100; i++ {
+			atomic.AddInt64(&read, 1)
+			if !yield(i) {
+				return
+			}
+		}
+	}
+
+	for range ParallelMap(source, func(x int) int { return x }, ParallelOptions{Concurrency: 2, BufferSize: 3}) {
+		// let the workers fill the buffer
+		time.Sleep(5 * time.Millisecond)
+		break
+	}
+
+	// the consumed item, BufferSize pending items, and the one blocked in the producer
+	is.LessOrEqual(atomic.LoadInt64(&read), int64(5))
+}
+
+func TestParallelMapConcurrency(t *testing.T) { //nolint:paralleltest
+	// t.Parallel()
+	is := assert.New(t)
+
+	var mu sync.Mutex
+	running, peak := 0, 0
+
+	result := ParallelMap(RangeFrom(0, 50), func(x int) int {
+		mu.Lock()
+		running++
+		peak = max(peak, running)
+		mu.Unlock()
+
+		time.Sleep(time.Millisecond)
+
+		mu.Lock()
+		running--
+		mu.Unlock()
+		return x
+	}, ParallelOptions{Concurrency: 3, BufferSize: 10})
+
+	is.Len(slices.Collect(result), 50)
+	is.LessOrEqual(peak, 3)
+}
+
+func TestParallelMapPanics(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	is.PanicsWithValue("boom", func() {
+		_ = slices.Collect(ParallelMap(RangeFrom(0, 100), func(x int) int {
+			if x == 42 {
+				panic("boom")
+			}
+			return x
+		}, ParallelOptions{Concurrency: 4}))
+	})
+
+	var source iter.Seq[int] = func(yield func(int) bool) {
+		_ = yield(1)
+		panic("source")
+	}
+
+	is.PanicsWithValue("source", func() {
+		_ = slices.Collect(ParallelMap(source, func(x int) int { return x }, ParallelOptions{}))
+	})
+}
+
+func TestParallelMapNoLeak(t *testing.T) { //nolint:paralleltest
+	defer goleak.VerifyNone(t)
+
+	for range ParallelMap(RangeFrom(0, 1000), func(x int) int { return x }, ParallelOptions{Concurrency: 8, BufferSize: 4}) {
+		break
+	}
+
+	for range ParallelMap(RangeFrom(0, 1000), func(x int) int { return x }, ParallelOptions{Concurrency: 8, Unordered: true}) {
+		break
+	}
+}
+
+func TestParallelMapConsumerPanicNoLeak(t *testing.T) { //nolint:paralleltest
+	defer goleak.VerifyNone(t)
+	is := assert.New(t)
+
+	is.PanicsWithValue("consumer", func() {
+		for range ParallelMap(RangeFrom(0, 1000), func(x int) int { return x }, ParallelOptions{Concurrency: 8, BufferSize: 4}) {
+			panic("consumer")
+		}
+	})
+
+	is.PanicsWithValue("consumer", func() {
+		for range ParallelFilterMap(RangeFrom(0, 1000), func(x int) (int, bool) { return x, true }, ParallelOptions{Concurrency: 8, Unordered: true}) {
+			panic("consumer")
+		}
+	})
+}
+
+func TestParallelFilterMap(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	callback := func(x int) (string, bool) {
+		return strconv.Itoa(x), x%3 == 0
+	}
+
+	result := ParallelFilterMap(RangeFrom(0, 100), callback, ParallelOptions{Concurrency: 4})
+	is.Equal(slices.Collect(FilterMap(RangeFrom(0, 100), callback)), slices.Collect(result))
+
+	unordered := slices.Collect(ParallelFilterMap(RangeFrom(0, 100), callback, ParallelOptions{Unordered: true}))
+	is.ElementsMatch(slices.Collect(FilterMap(RangeFrom(0, 100), callback)), unordered)
+}
+
+func TestParallelForEach(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	var sum int64
+	ParallelForEach(RangeFrom(1, 100), func(x int) {
+		atomic.AddInt64(&sum, int64(x))
+	}, ParallelOptions{Concurrency: 4})
+
+	is.Equal(int64(5050), atomic.LoadInt64(&sum))
+}
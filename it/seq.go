@@ -387,6 +387,27 @@ func Sliding[T any](collection iter.Seq[T], size, step int) iter.Seq[[]T] {
 	}
 }
 
+// TimeWindow groups the elements of a sequence into the time windows of the aggregator, and yields each
+// window once closed. When the sequence ends, the open windows are flushed. The aggregator must not be
+// reused: iterating the result twice continues from its state.
+func TimeWindow[T, R any](collection iter.Seq[T], windows *lo.TimeWindows[T, R]) iter.Seq[lo.TimeWindow[R]] {
+	return func(yield func(lo.TimeWindow[R]) bool) {
+		for item := range collection {
+			for _, window := range windows.Add(item) {
+				if !yield(window) {
+					return
+				}
+			}
+		}
+
+		for _, window := range windows.Flush() {
+			if !yield(window) {
+				return
+			}
+		}
+	}
+}
+
 // PartitionBy returns a sequence of elements split into groups. The order of grouped values is
 // determined by the order they occur in collection. The grouping is generated from the results
 // of running each element of collection through transform.
@@ -11,7 +11,9 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -2391,3 +2393,42 @@ func TestSeqToSeq2(t *testing.T) {
 		})
 	}
 }
+
+func TestTimeWindow(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	origin := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(seconds int) time.Time {
+		return origin.Add(time.Duration(seconds) * time.Second)
+	}
+
+	newWindows := func() *lo.TimeWindows[time.Time, int] {
+		return lo.NewHoppingWindows(10*time.Second, 5*time.Second, func(agg int, item time.Time) int {
+			return agg + 1
+		}, 0, lo.TimeWindowOptions[time.Time]{
+			Timestamp: func(item time.Time) time.Time {
+				return item
+			},
+		})
+	}
+
+	events := slices.Values([]time.Time{at(1), at(7), at(12)})
+
+	is.Equal([]lo.TimeWindow[int]{
+		{Start: at(-5), End: at(5), Count: 1, Value: 1},
+		{Start: at(0), End: at(10), Count: 2, Value: 2},
+		{Start: at(5), End: at(15), Count: 2, Value: 2},
+		{Start: at(10), End: at(20), Count: 1, Value: 1},
+	}, slices.Collect(TimeWindow(events, newWindows())))
+
+	// early break
+	var first []lo.TimeWindow[int]
+	for window := range TimeWindow(events, newWindows()) {
+		first = append(first, window)
+		break
+	}
+	is.Equal([]lo.TimeWindow[int]{{Start: at(-5), End: at(5), Count: 1, Value: 1}}, first)
+
+	is.Empty(slices.Collect(TimeWindow(slices.Values([]time.Time{}), newWindows())))
+}
@@ -1,12 +1,15 @@
 package lo
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -1117,6 +1120,39 @@ func ExampleFindOrElse_found() {
 	// Output: 4
 }
 
+func ExampleBinarySearch() {
+	list := []int{1, 3, 3, 5, 8}
+
+	index, found := BinarySearch(list, 3)
+	missing, ok := BinarySearch(list, 4)
+
+	fmt.Printf("%v %v %v %v", index, found, missing, ok)
+	// Output: 1 true 3 false
+}
+
+func ExampleBinarySearchBy() {
+	type Event struct {
+		Name string
+		Time int
+	}
+
+	events := []Event{{"boot", 10}, {"login", 20}, {"logout", 40}}
+
+	index, found := BinarySearchBy(events, 20, func(e Event) int {
+		return e.Time
+	})
+
+	fmt.Printf("%v %v", index, found)
+	// Output: 1 true
+}
+
+func ExampleLowerBound() {
+	list := []int{1, 3, 3, 5, 8}
+
+	fmt.Printf("%v %v", LowerBound(list, 3), UpperBound(list, 3))
+	// Output: 1 3
+}
+
 func ExampleFindKey() {
 	users := map[string]int{
 		"Alice":   25,
@@ -1560,6 +1596,45 @@ func ExampleMaxIndexByErr() {
 	// Output: bob is not allowed
 }
 
+func ExampleTopK() {
+	result := TopK([]int{3, 9, 1, 7, 8, 2}, 3)
+
+	fmt.Printf("%v", result)
+	// Output: [9 8 7]
+}
+
+func ExampleTopKBy() {
+	type Player struct {
+		Name  string
+		Score int
+	}
+
+	players := []Player{{"Alice", 42}, {"Bob", 17}, {"Carol", 58}, {"Dave", 42}}
+
+	result := TopKBy(players, 2, func(a, b Player) bool {
+		return a.Score > b.Score
+	})
+
+	fmt.Printf("%v", result)
+	// Output: [{Carol 58} {Alice 42}]
+}
+
+func ExampleBottomK() {
+	result := BottomK([]int{3, 9, 1, 7, 8, 2}, 3)
+
+	fmt.Printf("%v", result)
+	// Output: [1 2 3]
+}
+
+func ExampleBottomKBy() {
+	result := BottomKBy([]string{"banana", "fig", "apple", "kiwi"}, 2, func(a, b string) bool {
+		return len(a) < len(b)
+	})
+
+	fmt.Printf("%v", result)
+	// Output: [fig kiwi]
+}
+
 func ExampleLatest() {
 	now := time.Now()
 	past := now.Add(-time.Hour)
@@ -1849,6 +1924,51 @@ func ExampleWithoutByErr() {
 	// [] user 2 extraction failed
 }
 
+func ExampleNewSet() {
+	set := NewSet("a", "b", "a")
+	set.Add("c")
+	set.Remove("b")
+
+	result := set.Slice()
+	sort.Strings(result)
+	fmt.Printf("%v %v %v", result, set.Has("a"), set.Has("b"))
+	// Output: [a c] true false
+}
+
+func ExampleSet_Union() {
+	result := NewSet(1, 2).Union(NewSet(2, 3), NewSet(4)).Slice()
+	sort.Ints(result)
+	fmt.Printf("%v", result)
+	// Output: [1 2 3 4]
+}
+
+func ExampleSet_Intersect() {
+	result := NewSet(1, 2, 3).Intersect(NewSet(2, 3, 4)).Slice()
+	sort.Ints(result)
+	fmt.Printf("%v", result)
+	// Output: [2 3]
+}
+
+func ExampleSet_Difference() {
+	result := NewSet(1, 2, 3).Difference(NewSet(2)).Slice()
+	sort.Ints(result)
+	fmt.Printf("%v", result)
+	// Output: [1 3]
+}
+
+func ExampleSet_SymmetricDifference() {
+	result := NewSet(1, 2, 3).SymmetricDifference(NewSet(3, 4)).Slice()
+	sort.Ints(result)
+	fmt.Printf("%v", result)
+	// Output: [1 2 4]
+}
+
+func ExampleSet_IsSubsetOf() {
+	set := NewSet(1, 2, 3)
+	fmt.Printf("%v %v", NewSet(1, 2).IsSubsetOf(set), set.IsSubsetOf(NewSet(1, 2)))
+	// Output: true false
+}
+
 func ExampleKeys() {
 	kv := map[string]int{"foo": 1, "bar": 2}
 	kv2 := map[string]int{"baz": 3}
@@ -2876,6 +2996,58 @@ func ExampleKeyBy() {
 	// Output: map[1:a 2:aa 3:aaa]
 }
 
+func ExampleKeyByOrdered() {
+	list := []string{"aaa", "a", "aa"}
+
+	result := KeyByOrdered(list, func(str string) int {
+		return len(str)
+	})
+
+	fmt.Printf("%v %v", result.Keys(), result.Values())
+	// Output: [3 1 2] [aaa a aa]
+}
+
+func ExampleGroupByOrdered() {
+	list := []int{5, 0, 1, 2, 3, 4}
+
+	result := GroupByOrdered(list, func(i int) int {
+		return i % 3
+	})
+
+	for _, entry := range result.Entries() {
+		fmt.Printf("%v: %v\n", entry.Key, entry.Value)
+	}
+	// Output:
+	// 2: [5 2]
+	// 0: [0 3]
+	// 1: [1 4]
+}
+
+func ExampleAssociateOrdered() {
+	list := []string{"banana", "apple", "cherry"}
+
+	result := AssociateOrdered(list, func(str string) (string, int) {
+		return str, len(str)
+	})
+
+	fmt.Printf("%v", result.Entries())
+	// Output: [{banana 6} {apple 5} {cherry 6}]
+}
+
+func ExampleNewOrderedMap() {
+	m := NewOrderedMap[string, int]()
+	m.Set("z", 1)
+	m.Set("a", 2)
+	m.Set("m", 3)
+	m.Delete("a")
+	m.Set("z", 4)
+
+	data, _ := json.Marshal(m)
+
+	fmt.Printf("%v %s", m.Keys(), data)
+	// Output: [z m] {"z":4,"m":3}
+}
+
 func ExampleKeyByErr() {
 	list := []string{"a", "aa", "aaa"}
 
@@ -3215,6 +3387,36 @@ func ExampleIsSortedBy() {
 	// Output: true
 }
 
+func ExampleInsertSorted() {
+	result := InsertSorted([]int{1, 3, 5}, 4, 0)
+
+	fmt.Printf("%v", result)
+	// Output: [0 1 3 4 5]
+}
+
+func ExampleMergeSorted() {
+	result := MergeSorted([]int{1, 4, 7}, []int{2, 5, 8}, []int{3, 6, 9})
+
+	fmt.Printf("%v", result)
+	// Output: [1 2 3 4 5 6 7 8 9]
+}
+
+func ExampleMergeSortedBy() {
+	type Event struct {
+		Source string
+		Time   int
+	}
+
+	result := MergeSortedBy(
+		func(e Event) int { return e.Time },
+		[]Event{{"api", 1}, {"api", 4}},
+		[]Event{{"db", 2}, {"db", 3}},
+	)
+
+	fmt.Printf("%v", result)
+	// Output: [{api 1} {db 2} {db 3} {api 4}]
+}
+
 func ExampleCut() {
 	collection := []string{"a", "b", "c", "d", "e", "f", "g"}
 
@@ -4149,3 +4351,187 @@ func ExampleIntersectBy() {
 	// Output:
 	// [0]
 }
+
+func ExampleIntersectSorted() {
+	result := IntersectSorted([]int{0, 1, 3, 5, 7}, []int{1, 3, 5}, []int{3, 5, 8})
+	fmt.Printf("%v", result)
+	// Output:
+	// [3 5]
+}
+
+func ExampleUnionSorted() {
+	result := UnionSorted([]int{0, 1, 3}, []int{1, 2, 5}, []int{3, 4})
+	fmt.Printf("%v", result)
+	// Output:
+	// [0 1 2 3 4 5]
+}
+
+func ExampleDifferenceSorted() {
+	left, right := DifferenceSorted([]int{0, 1, 2, 3, 4, 5}, []int{0, 2, 6})
+	fmt.Printf("%v %v", left, right)
+	// Output:
+	// [1 3 4 5] [6]
+}
+
+func ExampleNewPriorityQueue() {
+	pq := NewPriorityQueue(func(a, b int) bool {
+		return a < b
+	}, 5, 3, 8)
+	pq.Push(1)
+
+	head, _ := pq.Pop()
+
+	fmt.Printf("%v %v", head, pq.PopAll())
+	// Output: 1 [3 5 8]
+}
+
+func ExampleNewLRUCache() {
+	cache := NewLRUCache(2, time.Minute, func(key string, value int) {
+		fmt.Printf("evicted %s\n", key)
+	})
+
+	cache.Set("a", 1)
+	cache.Set("b", 2)
+	cache.Get("a")
+	cache.Set("c", 3)
+
+	_, ok := cache.Get("b")
+
+	fmt.Printf("%v %v %+v", cache.Keys(), ok, cache.Stats())
+	// Output:
+	// evicted b
+	// [c a] false {Hits:1 Misses:1 Evictions:1 Expirations:0}
+}
+
+func ExampleMemoize() {
+	calls := 0
+	square := Memoize(func(n int) int {
+		calls++
+		return n * n
+	})
+
+	fmt.Printf("%v %v %v", square(3), square(3), calls)
+	// Output: 9 9 1
+}
+
+func ExampleMemoizeWithCache() {
+	cache := NewLRUCache[string, int](100, time.Minute)
+
+	length := MemoizeWithCache(cache, func(s string) int {
+		return len(s)
+	})
+
+	length("hello")
+	length("hello")
+
+	fmt.Printf("%+v", cache.Stats())
+	// Output: {Hits:1 Misses:1 Evictions:0 Expirations:0}
+}
+
+func ExampleNewSynchronizeBy() {
+	s := NewSynchronizeBy[string]()
+	balances := map[string]int{"alice": 100}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.Do("alice", func() {
+				balances["alice"] -= 10
+			})
+		}()
+	}
+	wg.Wait()
+
+	fmt.Printf("%v", balances["alice"])
+	// Output: 0
+}
+
+func ExampleNewRWSynchronizeBy() {
+	s := NewRWSynchronizeBy[string]()
+
+	s.RDo("alice", func() {
+		fmt.Println(s.TryRDo("alice", 0, func() {}))
+		fmt.Println(s.TryDo("alice", 10*time.Millisecond, func() {}))
+	})
+	// Output:
+	// true
+	// false
+}
+
+func ExampleNewSemaphore() {
+	s := NewSemaphore(3)
+
+	_ = s.Acquire(context.Background(), 2)
+	fmt.Println(s.TryAcquire(2))
+	s.Release(2)
+	fmt.Println(s.TryAcquire(2))
+	// Output:
+	// false
+	// true
+}
+
+func ExampleNewWorkerPool() {
+	pool := NewWorkerPool[int](WorkerPoolOptions{Workers: 2, QueueSize: 10})
+
+	for i := 1; i <= 3; i++ {
+		i := i
+		_ = pool.Submit(func() (int, error) {
+			return i * i, nil
+		})
+	}
+
+	go func() {
+		_ = pool.Shutdown(context.Background())
+	}()
+
+	sum := 0
+	for result := range pool.Results() {
+		sum += result.A
+	}
+
+	fmt.Println(sum)
+	// Output: 14
+}
+
+func ExampleNewSingleflight() {
+	sf := NewSingleflight[string, string]()
+
+	config, err := sf.Do("config", func() (string, error) {
+		return "loaded once", nil
+	})
+
+	fmt.Printf("%v %v %v", config, err, sf.InFlight("config"))
+	// Output: loaded once <nil> false
+}
+
+func ExampleNewFuture() {
+	user := NewFuture(func() (string, error) {
+		return "samuel", nil
+	})
+	orders := NewFuture(func() (string, error) {
+		return "", errors.New("orders service unavailable")
+	}).Catch(func(err error) (string, error) {
+		return "no orders", nil
+	})
+
+	results, err := FutureAll(user, orders).Await(context.Background())
+
+	fmt.Printf("%v %v", results, err)
+	// Output: [samuel no orders] <nil>
+}
+
+func ExampleFutureAny() {
+	primary := NewFuture(func() (string, error) {
+		return "", errors.New("timeout")
+	})
+	replica := NewFuture(func() (string, error) {
+		return "replica", nil
+	})
+
+	result, err := FutureAny(primary, replica).Await(context.Background())
+
+	fmt.Printf("%v %v", result, err)
+	// Output: replica <nil>
+}
@@ -0,0 +1,353 @@
+package lo
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+type orderedMapEntry[K comparable, V any] struct {
+	key   K
+	value V
+	prev  *orderedMapEntry[K, V]
+	next  *orderedMapEntry[K, V]
+}
+
+// OrderedMap is a map that remembers the insertion order of its keys.
+// Updating the value of an existing key keeps its position.
+// The zero value is an empty map ready to use. An OrderedMap is not safe for concurrent use.
+type OrderedMap[K comparable, V any] struct {
+	entries map[K]*orderedMapEntry[K, V]
+	head    *orderedMapEntry[K, V]
+	tail    *orderedMapEntry[K, V]
+}
+
+// NewOrderedMap creates an OrderedMap containing the given entries, in order.
+func NewOrderedMap[K comparable, V any](entries ...Entry[K, V]) *OrderedMap[K, V] {
+	m := &OrderedMap[K, V]{
+		entries: make(map[K]*orderedMapEntry[K, V], len(entries)),
+	}
+
+	for i := range entries {
+		m.Set(entries[i].Key, entries[i].Value)
+	}
+
+	return m
+}
+
+// Set adds or updates a key. A new key is appended at the end of the map.
+func (m *OrderedMap[K, V]) Set(key K, value V) {
+	if e, ok := m.entries[key]; ok {
+		e.value = value
+		return
+	}
+
+	if m.entries == nil {
+		m.entries = map[K]*orderedMapEntry[K, V]{}
+	}
+
+	e := &orderedMapEntry[K, V]{
+		key:   key,
+		value: value,
+		prev:  m.tail,
+	}
+
+	if m.tail == nil {
+		m.head = e
+	} else {
+		m.tail.next = e
+	}
+
+	m.tail = e
+	m.entries[key] = e
+}
+
+// Get returns the value of a key and whether it was found.
+func (m *OrderedMap[K, V]) Get(key K) (V, bool) {
+	if e, ok := m.entries[key]; ok {
+		return e.value, true
+	}
+
+	var zero V
+	return zero, false
+}
+
+// GetOr returns the value of a key, or the fallback value when the key is missing.
+func (m *OrderedMap[K, V]) GetOr(key K, fallback V) V {
+	if e, ok := m.entries[key]; ok {
+		return e.value
+	}
+
+	return fallback
+}
+
+// Has returns true if the key is present.
+func (m *OrderedMap[K, V]) Has(key K) bool {
+	_, ok := m.entries[key]
+	return ok
+}
+
+// Delete removes a key and returns true if it was present.
+func (m *OrderedMap[K, V]) Delete(key K) bool {
+	e, ok := m.entries[key]
+	if !ok {
+		return false
+	}
+
+	if e.prev == nil {
+		m.head = e.next
+	} else {
+		e.prev.next = e.next
+	}
+
+	if e.next == nil {
+		m.tail = e.prev
+	} else {
+		e.next.prev = e.prev
+	}
+
+	delete(m.entries, key)
+
+	return true
+}
+
+// Len returns the number of keys.
+func (m *OrderedMap[K, V]) Len() int {
+	return len(m.entries)
+}
+
+// Keys returns the keys in insertion order.
+func (m *OrderedMap[K, V]) Keys() []K {
+	result := make([]K, 0, len(m.entries))
+
+	for e := m.head; e != nil; e = e.next {
+		result = append(result, e.key)
+	}
+
+	return result
+}
+
+// Values returns the values in insertion order.
+func (m *OrderedMap[K, V]) Values() []V {
+	result := make([]V, 0, len(m.entries))
+
+	for e := m.head; e != nil; e = e.next {
+		result = append(result, e.value)
+	}
+
+	return result
+}
+
+// Entries returns the key/value pairs in insertion order.
+func (m *OrderedMap[K, V]) Entries() []Entry[K, V] {
+	result := make([]Entry[K, V], 0, len(m.entries))
+
+	for e := m.head; e != nil; e = e.next {
+		result = append(result, Entry[K, V]{Key: e.key, Value: e.value})
+	}
+
+	return result
+}
+
+// ForEach invokes callback for each key/value pair in insertion order.
+func (m *OrderedMap[K, V]) ForEach(callback func(key K, value V)) {
+	for e := m.head; e != nil; e = e.next {
+		callback(e.key, e.value)
+	}
+}
+
+// ToMap returns a regular map with the same key/value pairs.
+func (m *OrderedMap[K, V]) ToMap() map[K]V {
+	result := make(map[K]V, len(m.entries))
+
+	for e := m.head; e != nil; e = e.next {
+		result[e.key] = e.value
+	}
+
+	return result
+}
+
+// MarshalJSON encodes the map as a JSON object, with keys in insertion order.
+// Keys follow the encoding/json rules: strings, integers or encoding.TextMarshaler.
+func (m *OrderedMap[K, V]) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+
+	buf.WriteByte('{')
+
+	for e := m.head; e != nil; e = e.next {
+		if e != m.head {
+			buf.WriteByte(',')
+		}
+
+		key, err := marshalOrderedMapKey(e.key)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := json.Marshal(e.value)
+		if err != nil {
+			return nil, err
+		}
+
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON decodes a JSON object, preserving the order of its keys.
+// Existing keys are kept and updated in place.
+func (m *OrderedMap[K, V]) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	if token == nil {
+		return nil
+	}
+
+	if delim, ok := token.(json.Delim); !ok || delim != '{' {
+		return errors.New("lo.OrderedMap: expected a JSON object")
+	}
+
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return err
+		}
+
+		rawKey, ok := token.(string)
+		if !ok {
+			return errors.New("lo.OrderedMap: expected a JSON object key")
+		}
+
+		key, err := unmarshalOrderedMapKey[K](rawKey)
+		if err != nil {
+			return err
+		}
+
+		var value V
+		if err := dec.Decode(&value); err != nil {
+			return err
+		}
+
+		m.Set(key, value)
+	}
+
+	_, err = dec.Token()
+	return err
+}
+
+// marshalOrderedMapKey encodes a key like encoding/json encodes the keys of a map:
+// string kinds first, then encoding.TextMarshaler, then integers.
+func marshalOrderedMapKey[K comparable](key K) ([]byte, error) {
+	v := reflect.ValueOf(key)
+
+	if v.Kind() == reflect.String {
+		return json.Marshal(v.String())
+	}
+
+	if tm, ok := any(key).(encoding.TextMarshaler); ok {
+		text, err := tm.MarshalText()
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(string(text))
+	}
+
+	switch v.Kind() { //nolint:exhaustive
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return json.Marshal(strconv.FormatInt(v.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return json.Marshal(strconv.FormatUint(v.Uint(), 10))
+	default:
+		return nil, fmt.Errorf("lo.OrderedMap: unsupported key type %T", key)
+	}
+}
+
+func unmarshalOrderedMapKey[K comparable](raw string) (K, error) {
+	var key K
+
+	if tu, ok := any(&key).(encoding.TextUnmarshaler); ok {
+		err := tu.UnmarshalText([]byte(raw))
+		return key, err
+	}
+
+	v := reflect.ValueOf(&key).Elem()
+
+	switch v.Kind() { //nolint:exhaustive
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, v.Type().Bits())
+		if err != nil {
+			return key, err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(raw, 10, v.Type().Bits())
+		if err != nil {
+			return key, err
+		}
+		v.SetUint(n)
+	default:
+		return key, fmt.Errorf("lo.OrderedMap: unsupported key type %T", key)
+	}
+
+	return key, nil
+}
+
+// GroupByOrdered is like GroupBy, but returns an OrderedMap whose keys follow
+// the order of their first occurrence in the collection.
+func GroupByOrdered[T any, U comparable, Slice ~[]T](collection Slice, iteratee func(item T) U) *OrderedMap[U, Slice] {
+	result := NewOrderedMap[U, Slice]()
+
+	for i := range collection {
+		key := iteratee(collection[i])
+
+		if e, ok := result.entries[key]; ok {
+			e.value = append(e.value, collection[i])
+		} else {
+			result.Set(key, Slice{collection[i]})
+		}
+	}
+
+	return result
+}
+
+// KeyByOrdered is like KeyBy, but returns an OrderedMap whose keys follow
+// the order of their first occurrence in the collection. When several items share
+// the same key, the last one is kept.
+func KeyByOrdered[K comparable, V any](collection []V, iteratee func(item V) K) *OrderedMap[K, V] {
+	result := NewOrderedMap[K, V]()
+
+	for i := range collection {
+		result.Set(iteratee(collection[i]), collection[i])
+	}
+
+	return result
+}
+
+// AssociateOrdered is like Associate, but returns an OrderedMap whose keys follow
+// the order of their first occurrence in the collection. When several pairs share
+// the same key, the last value is kept.
+func AssociateOrdered[T any, K comparable, V any](collection []T, transform func(item T) (K, V)) *OrderedMap[K, V] {
+	result := NewOrderedMap[K, V]()
+
+	for i := range collection {
+		result.Set(transform(collection[i]))
+	}
+
+	return result
+}
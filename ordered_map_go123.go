@@ -0,0 +1,16 @@
+//go:build go1.23
+
+package lo
+
+import "iter"
+
+// All returns a sequence of the key/value pairs in insertion order.
+func (m *OrderedMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for e := m.head; e != nil; e = e.next {
+			if !yield(e.key, e.value) {
+				return
+			}
+		}
+	}
+}
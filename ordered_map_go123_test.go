@@ -0,0 +1,32 @@
+//go:build go1.23
+
+package lo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderedMapAll(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	m := NewOrderedMap(Entry[string, int]{"c", 3}, Entry[string, int]{"a", 1}, Entry[string, int]{"b", 2})
+
+	keys := []string{}
+	values := []int{}
+	for k, v := range m.All() {
+		keys = append(keys, k)
+		values = append(values, v)
+	}
+	is.Equal([]string{"c", "a", "b"}, keys)
+	is.Equal([]int{3, 1, 2}, values)
+
+	count := 0
+	for range m.All() {
+		count++
+		break
+	}
+	is.Equal(1, count)
+}
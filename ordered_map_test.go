@@ -0,0 +1,218 @@
+package lo
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type textKey struct {
+	name string
+}
+
+func (k textKey) MarshalText() ([]byte, error) {
+	return []byte("key-" + k.name), nil
+}
+
+func (k *textKey) UnmarshalText(text []byte) error {
+	k.name = strings.TrimPrefix(string(text), "key-")
+	return nil
+}
+
+// stringTextKey has a string kind, which takes precedence over MarshalText when encoding the keys.
+type stringTextKey string
+
+func (k stringTextKey) MarshalText() ([]byte, error) {
+	return []byte("key-" + k), nil
+}
+
+func TestOrderedMap(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	m := NewOrderedMap[string, int]()
+	m.Set("z", 1)
+	m.Set("a", 2)
+	m.Set("m", 3)
+	m.Set("z", 4)
+
+	is.Equal(3, m.Len())
+	is.Equal([]string{"z", "a", "m"}, m.Keys())
+	is.Equal([]int{4, 2, 3}, m.Values())
+	is.Equal([]Entry[string, int]{{"z", 4}, {"a", 2}, {"m", 3}}, m.Entries())
+	is.Equal(map[string]int{"z": 4, "a": 2, "m": 3}, m.ToMap())
+
+	v, ok := m.Get("a")
+	is.True(ok)
+	is.Equal(2, v)
+
+	v, ok = m.Get("b")
+	is.False(ok)
+	is.Zero(v)
+	is.Equal(42, m.GetOr("b", 42))
+	is.Equal(2, m.GetOr("a", 42))
+	is.True(m.Has("m"))
+	is.False(m.Has("b"))
+
+	is.True(m.Delete("a"))
+	is.False(m.Delete("a"))
+	is.Equal([]string{"z", "m"}, m.Keys())
+
+	is.True(m.Delete("z"))
+	is.Equal([]string{"m"}, m.Keys())
+	is.True(m.Delete("m"))
+	is.Empty(m.Keys())
+
+	m.Set("b", 1)
+	m.Set("c", 2)
+	is.Equal([]string{"b", "c"}, m.Keys())
+
+	keys := []string{}
+	m.ForEach(func(key string, value int) {
+		keys = append(keys, key)
+	})
+	is.Equal([]string{"b", "c"}, keys)
+}
+
+func TestOrderedMapZeroValue(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	var m OrderedMap[int, string]
+	is.Zero(m.Len())
+	is.False(m.Delete(1))
+	is.Empty(m.Keys())
+
+	m.Set(2, "b")
+	m.Set(1, "a")
+	is.Equal([]int{2, 1}, m.Keys())
+}
+
+func TestOrderedMapJSON(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	m := NewOrderedMap(Entry[string, int]{"z", 1}, Entry[string, int]{"a", 2}, Entry[string, int]{"m", 3})
+
+	data, err := json.Marshal(m)
+	is.NoError(err)
+	is.JSONEq(`{"z":1,"a":2,"m":3}`, string(data))
+	is.Equal(`{"z":1,"a":2,"m":3}`, string(data))
+
+	data, err = json.Marshal(NewOrderedMap[string, int]())
+	is.NoError(err)
+	is.Equal(`{}`, string(data))
+
+	data, err = json.Marshal(NewOrderedMap(Entry[int, []string]{10, []string{"x"}}, Entry[int, []string]{-1, nil}))
+	is.NoError(err)
+	is.Equal(`{"10":["x"],"-1":null}`, string(data))
+
+	data, err = json.Marshal(NewOrderedMap(Entry[textKey, bool]{textKey{"b"}, true}, Entry[textKey, bool]{textKey{"a"}, false}))
+	is.NoError(err)
+	is.Equal(`{"key-b":true,"key-a":false}`, string(data))
+
+	data, err = json.Marshal(NewOrderedMap(Entry[stringTextKey, int]{"a", 1}))
+	is.NoError(err)
+	is.Equal(`{"a":1}`, string(data))
+
+	type key struct{ a int }
+	_, err = json.Marshal(NewOrderedMap(Entry[key, int]{key{1}, 1}))
+	is.Error(err)
+}
+
+func TestOrderedMapUnmarshalJSON(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	m := NewOrderedMap[string, []int]()
+	err := json.Unmarshal([]byte(`{"z":[1],"a":[2, 3],"m":null}`), m)
+	is.NoError(err)
+	is.Equal([]string{"z", "a", "m"}, m.Keys())
+	is.Equal([][]int{{1}, {2, 3}, nil}, m.Values())
+
+	m2 := NewOrderedMap[uint8, string]()
+	err = json.Unmarshal([]byte(`{"3":"c","1":"a"}`), m2)
+	is.NoError(err)
+	is.Equal([]uint8{3, 1}, m2.Keys())
+
+	m3 := NewOrderedMap[int, string]()
+	m4 := NewOrderedMap[textKey, int]()
+	err = json.Unmarshal([]byte(`{"key-b":1,"key-a":2}`), m4)
+	is.NoError(err)
+	is.Equal([]textKey{{"b"}, {"a"}}, m4.Keys())
+
+	err = json.Unmarshal([]byte(`{"a":"c"}`), m3)
+	is.Error(err)
+
+	err = json.Unmarshal([]byte(`[1, 2]`), m3)
+	is.Error(err)
+
+	err = json.Unmarshal([]byte(`{"1": 1}`), m3)
+	is.Error(err)
+
+	type payload struct {
+		Data *OrderedMap[string, int] `json:"data"`
+	}
+
+	var p payload
+	err = json.NewDecoder(strings.NewReader(`{"data":{"b":1,"a":2}}`)).Decode(&p)
+	is.NoError(err)
+	is.Equal([]string{"b", "a"}, p.Data.Keys())
+
+	p = payload{}
+	err = json.Unmarshal([]byte(`{"data":null}`), &p)
+	is.NoError(err)
+	is.Nil(p.Data)
+}
+
+func TestGroupByOrdered(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	result := GroupByOrdered([]int{5, 0, 1, 2, 3, 4}, func(i int) int {
+		return i % 3
+	})
+
+	is.Equal([]int{2, 0, 1}, result.Keys())
+	is.Equal([][]int{{5, 2}, {0, 3}, {1, 4}}, result.Values())
+	is.Equal(GroupBy([]int{5, 0, 1, 2, 3, 4}, func(i int) int {
+		return i % 3
+	}), result.ToMap())
+
+	type myStrings []string
+	allStrings := myStrings{"", "foo", "bar"}
+	nonempty := GroupByOrdered(allStrings, func(i string) int {
+		return 42
+	})
+	is.IsType(nonempty.Values()[0], allStrings, "type preserved")
+}
+
+func TestKeyByOrdered(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	result := KeyByOrdered([]string{"aaa", "a", "aa", "b"}, func(str string) int {
+		return len(str)
+	})
+
+	is.Equal([]int{3, 1, 2}, result.Keys())
+	is.Equal([]string{"aaa", "b", "aa"}, result.Values())
+}
+
+func TestAssociateOrdered(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	type foo struct {
+		baz string
+		bar int
+	}
+
+	result := AssociateOrdered([]*foo{{baz: "banana", bar: 2}, {baz: "apple", bar: 1}, {baz: "banana", bar: 3}}, func(f *foo) (string, int) {
+		return f.baz, f.bar
+	})
+
+	is.Equal([]Entry[string, int]{{"banana", 3}, {"apple", 1}}, result.Entries())
+}
@@ -0,0 +1,176 @@
+package parallel
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/samber/lo/internal/xerrors"
+)
+
+// Options bounds the amount of goroutines started by the `*WithOptions` and `*WithContext` helpers.
+type Options struct {
+	// Concurrency is the maximum number of workers running at the same time.
+	// When lower than 1, it defaults to runtime.GOMAXPROCS(0).
+	Concurrency int
+
+	// ChunkSize is the number of consecutive items processed by a worker before
+	// picking the next chunk. Large chunks reduce scheduling overhead for cheap callbacks.
+	// When lower than 1, the collection is split into 4 chunks per worker.
+	ChunkSize int
+
+	// JoinErrors makes the error-aware helpers run every callback and return all
+	// errors joined with errors.Join, instead of stopping at the first error.
+	JoinErrors bool
+}
+
+// normalize returns the effective number of workers and chunk size for a collection of `size` items.
+func (o Options) normalize(size int) (workers, chunkSize int) {
+	workers = o.Concurrency
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	chunkSize = o.ChunkSize
+	if chunkSize < 1 {
+		chunkSize = (size + workers*4 - 1) / (workers * 4)
+		if chunkSize < 1 {
+			chunkSize = 1
+		}
+	}
+
+	chunks := (size + chunkSize - 1) / chunkSize
+	if workers > chunks {
+		workers = chunks
+	}
+
+	return workers, chunkSize
+}
+
+// forEachChunk splits the [0, size) range into chunks and processes them with a bounded
+// pool of workers. It blocks until every chunk has been processed.
+func forEachChunk(size int, opts Options, callback func(start, end int)) {
+	if size <= 0 {
+		return
+	}
+
+	workers, chunkSize := opts.normalize(size)
+	chunks := int64((size + chunkSize - 1) / chunkSize)
+
+	var next int64
+	var wg sync.WaitGroup
+	wg.Add(workers)
+
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+
+			for {
+				chunk := atomic.AddInt64(&next, 1) - 1
+				if chunk >= chunks {
+					return
+				}
+
+				start := int(chunk) * chunkSize
+				end := start + chunkSize
+				if end > size {
+					end = size
+				}
+
+				callback(start, end)
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+type indexedError struct {
+	index int
+	err   error
+}
+
+// forEachIndexErr calls callback for every index of [0, size) with a bounded pool of workers.
+// Unless `opts.JoinErrors` is set, no more callback is started after the first error.
+// Cancelling the context stops the scheduling as well. Panics are returned as errors.
+func forEachIndexErr(ctx context.Context, size int, opts Options, callback func(ctx context.Context, index int) error) error {
+	if size <= 0 {
+		return ctx.Err()
+	}
+
+	childCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var mu sync.Mutex
+	var errs []indexedError
+	var done int64
+
+	forEachChunk(size, opts, func(start, end int) {
+		for i := start; i < end; i++ {
+			if childCtx.Err() != nil {
+				return
+			}
+
+			err := recoverError(func() error {
+				return callback(childCtx, i)
+			})
+			atomic.AddInt64(&done, 1)
+
+			if err != nil {
+				mu.Lock()
+				errs = append(errs, indexedError{index: i, err: err})
+				mu.Unlock()
+
+				if !opts.JoinErrors {
+					cancel()
+				}
+			}
+		}
+	})
+
+	canceled := atomic.LoadInt64(&done) < int64(size)
+
+	if len(errs) == 0 {
+		if canceled {
+			return ctx.Err()
+		}
+		return nil
+	}
+
+	if !opts.JoinErrors {
+		return errs[0].err
+	}
+
+	sort.Slice(errs, func(i, j int) bool {
+		return errs[i].index < errs[j].index
+	})
+
+	joined := make([]error, 0, len(errs)+1)
+	for i := range errs {
+		joined = append(joined, errs[i].err)
+	}
+
+	if canceled {
+		joined = append(joined, ctx.Err())
+	}
+
+	return xerrors.Join(joined...)
+}
+
+// recoverError calls the callback and turns a panic into an error.
+func recoverError(callback func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if e, ok := r.(error); ok {
+				err = fmt.Errorf("parallel: recovered from panic: %w", e)
+			} else {
+				err = fmt.Errorf("parallel: recovered from panic: %v", r)
+			}
+		}
+	}()
+
+	return callback()
+}
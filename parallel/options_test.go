@@ -0,0 +1,91 @@
+package parallel
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptionsNormalize(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	workers, chunkSize := Options{Concurrency: 4, ChunkSize: 10}.normalize(100)
+	is.Equal(4, workers)
+	is.Equal(10, chunkSize)
+
+	workers, chunkSize = Options{Concurrency: 4}.normalize(100)
+	is.Equal(4, workers)
+	is.Equal(7, chunkSize)
+
+	workers, chunkSize = Options{Concurrency: 8, ChunkSize: 50}.normalize(100)
+	is.Equal(2, workers)
+	is.Equal(50, chunkSize)
+
+	workers, chunkSize = Options{}.normalize(1)
+	is.Equal(1, workers)
+	is.Equal(1, chunkSize)
+
+	workers, _ = Options{}.normalize(1_000_000)
+	is.Equal(runtime.GOMAXPROCS(0), workers)
+}
+
+func TestForEachChunk(t *testing.T) {
+	t.Parallel()
+
+	t.Run("visits every index once", func(t *testing.T) {
+		t.Parallel()
+		is := assert.New(t)
+
+		visited := make([]int32, 1000)
+		forEachChunk(len(visited), Options{Concurrency: 3, ChunkSize: 7}, func(start, end int) {
+			for i := start; i < end; i++ {
+				atomic.AddInt32(&visited[i], 1)
+			}
+		})
+
+		for i := range visited {
+			is.Equal(int32(1), visited[i])
+		}
+	})
+
+	t.Run("bounds concurrency", func(t *testing.T) {
+		t.Parallel()
+		is := assert.New(t)
+
+		var mu sync.Mutex
+		running, peak := 0, 0
+
+		forEachChunk(200, Options{Concurrency: 3, ChunkSize: 1}, func(start, end int) {
+			mu.Lock()
+			running++
+			if running > peak {
+				peak = running
+			}
+			mu.Unlock()
+
+			runtime.Gosched()
+
+			mu.Lock()
+			running--
+			mu.Unlock()
+		})
+
+		is.LessOrEqual(peak, 3)
+		is.Equal(0, running)
+	})
+
+	t.Run("empty range", func(t *testing.T) {
+		t.Parallel()
+		is := assert.New(t)
+
+		called := false
+		forEachChunk(0, Options{}, func(start, end int) {
+			called = true
+		})
+		is.False(called)
+	})
+}
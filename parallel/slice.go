@@ -1,6 +1,13 @@
 package parallel
 
-import "sync"
+import (
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/samber/lo/internal/constraints"
+)
 
 // Map manipulates a slice and transforms it to a slice of another type.
 // `transform` is called in parallel. Result keep the same order.
@@ -26,6 +33,52 @@ func Map[T, R any](collection []T, transform func(item T, index int) R) []R {
 	return result
 }
 
+// MapWithOptions is like Map, but the number of goroutines is bounded by `opts.Concurrency`
+// and the collection is split into chunks of `opts.ChunkSize` items. Result keep the same order.
+func MapWithOptions[T, R any](collection []T, transform func(item T, index int) R, opts Options) []R {
+	result := make([]R, len(collection))
+
+	forEachChunk(len(collection), opts, func(start, end int) {
+		for i := start; i < end; i++ {
+			result[i] = transform(collection[i], i)
+		}
+	})
+
+	return result
+}
+
+// MapErr manipulates a slice and transforms it to a slice of another type.
+// `transform` is called in parallel by at most runtime.GOMAXPROCS(0) goroutines. Result keep the same order.
+// No more transform is started after the first error, which is returned along with a nil slice.
+// Panics are recovered and returned as errors.
+func MapErr[T, R any](collection []T, transform func(item T, index int) (R, error)) ([]R, error) {
+	return MapErrWithContext(context.Background(), collection, func(_ context.Context, item T, index int) (R, error) {
+		return transform(item, index)
+	}, Options{})
+}
+
+// MapErrWithContext is like MapErr, but accepts a context and Options. The context passed to `transform`
+// is canceled on the first error or when the parent context is done. When `opts.JoinErrors` is set,
+// every transform runs and the errors are joined.
+func MapErrWithContext[T, R any](ctx context.Context, collection []T, transform func(ctx context.Context, item T, index int) (R, error), opts Options) ([]R, error) {
+	result := make([]R, len(collection))
+
+	err := forEachIndexErr(ctx, len(collection), opts, func(ctx context.Context, i int) error {
+		r, err := transform(ctx, collection[i], i)
+		if err != nil {
+			return err
+		}
+
+		result[i] = r
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
 // ForEach iterates over elements of collection and invokes callback for each element.
 // `iteratee` is called in parallel.
 // Play: https://go.dev/play/p/sCJaB3quRMC
@@ -43,6 +96,35 @@ func ForEach[T any](collection []T, callback func(item T, index int)) {
 	wg.Wait()
 }
 
+// ForEachWithOptions is like ForEach, but the number of goroutines is bounded by `opts.Concurrency`
+// and the collection is split into chunks of `opts.ChunkSize` items.
+func ForEachWithOptions[T any](collection []T, callback func(item T, index int), opts Options) {
+	forEachChunk(len(collection), opts, func(start, end int) {
+		for i := start; i < end; i++ {
+			callback(collection[i], i)
+		}
+	})
+}
+
+// ForEachErr iterates over elements of collection and invokes callback for each element.
+// `callback` is called in parallel by at most runtime.GOMAXPROCS(0) goroutines.
+// No more callback is started after the first error, which is returned.
+// Panics are recovered and returned as errors.
+func ForEachErr[T any](collection []T, callback func(item T, index int) error) error {
+	return ForEachErrWithContext(context.Background(), collection, func(_ context.Context, item T, index int) error {
+		return callback(item, index)
+	}, Options{})
+}
+
+// ForEachErrWithContext is like ForEachErr, but accepts a context and Options. The context passed to `callback`
+// is canceled on the first error or when the parent context is done. When `opts.JoinErrors` is set,
+// every callback runs and the errors are joined.
+func ForEachErrWithContext[T any](ctx context.Context, collection []T, callback func(ctx context.Context, item T, index int) error, opts Options) error {
+	return forEachIndexErr(ctx, len(collection), opts, func(ctx context.Context, i int) error {
+		return callback(ctx, collection[i], i)
+	})
+}
+
 // Times invokes the iteratee n times, returning a slice of the results of each invocation.
 // The iteratee is invoked with index as argument.
 // `iteratee` is called in parallel.
@@ -68,17 +150,85 @@ func Times[T any](count int, iteratee func(index int) T) []T {
 	return result
 }
 
+// TimesWithOptions is like Times, but the number of goroutines is bounded by `opts.Concurrency`
+// and the invocations are split into chunks of `opts.ChunkSize` indexes.
+func TimesWithOptions[T any](count int, iteratee func(index int) T, opts Options) []T {
+	if count < 0 {
+		count = 0
+	}
+
+	result := make([]T, count)
+
+	forEachChunk(count, opts, func(start, end int) {
+		for i := start; i < end; i++ {
+			result[i] = iteratee(i)
+		}
+	})
+
+	return result
+}
+
+// TimesErr invokes the iteratee n times, returning a slice of the results of each invocation.
+// `iteratee` is called in parallel by at most runtime.GOMAXPROCS(0) goroutines.
+// No more iteratee is started after the first error, which is returned along with a nil slice.
+// Panics are recovered and returned as errors.
+func TimesErr[T any](count int, iteratee func(index int) (T, error)) ([]T, error) {
+	return TimesErrWithContext(context.Background(), count, func(_ context.Context, index int) (T, error) {
+		return iteratee(index)
+	}, Options{})
+}
+
+// TimesErrWithContext is like TimesErr, but accepts a context and Options. The context passed to `iteratee`
+// is canceled on the first error or when the parent context is done. When `opts.JoinErrors` is set,
+// every iteratee runs and the errors are joined.
+func TimesErrWithContext[T any](ctx context.Context, count int, iteratee func(ctx context.Context, index int) (T, error), opts Options) ([]T, error) {
+	if count < 0 {
+		count = 0
+	}
+
+	result := make([]T, count)
+
+	err := forEachIndexErr(ctx, count, opts, func(ctx context.Context, i int) error {
+		item, err := iteratee(ctx, i)
+		if err != nil {
+			return err
+		}
+
+		result[i] = item
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
 // GroupBy returns an object composed of keys generated from the results of running each element of collection through iteratee.
 // The order of grouped values is determined by the order they occur in the collection.
 // `iteratee` is called in parallel.
 // Play: https://go.dev/play/p/EkyvA0gw4dj
 func GroupBy[T any, U comparable, Slice ~[]T](collection Slice, iteratee func(item T) U) map[U]Slice {
-	result := map[U]Slice{}
-
 	keys := Map(collection, func(item T, _ int) U {
 		return iteratee(item)
 	})
 
+	return groupByKeys(collection, keys)
+}
+
+// GroupByWithOptions is like GroupBy, but the number of goroutines is bounded by `opts.Concurrency`
+// and the collection is split into chunks of `opts.ChunkSize` items.
+func GroupByWithOptions[T any, U comparable, Slice ~[]T](collection Slice, iteratee func(item T) U, opts Options) map[U]Slice {
+	keys := MapWithOptions(collection, func(item T, _ int) U {
+		return iteratee(item)
+	}, opts)
+
+	return groupByKeys(collection, keys)
+}
+
+func groupByKeys[T any, U comparable, Slice ~[]T](collection Slice, keys []U) map[U]Slice {
+	result := map[U]Slice{}
+
 	for i, item := range collection {
 		result[keys[i]] = append(result[keys[i]], item)
 	}
@@ -93,13 +243,27 @@ func GroupBy[T any, U comparable, Slice ~[]T](collection Slice, iteratee func(it
 // `iteratee` is called in parallel.
 // Play: https://go.dev/play/p/GwBQdMgx2nC
 func PartitionBy[T any, K comparable, Slice ~[]T](collection Slice, iteratee func(item T) K) []Slice {
-	result := []Slice{}
-	seen := map[K]int{}
-
 	keys := Map(collection, func(item T, _ int) K {
 		return iteratee(item)
 	})
 
+	return partitionByKeys(collection, keys)
+}
+
+// PartitionByWithOptions is like PartitionBy, but the number of goroutines is bounded by `opts.Concurrency`
+// and the collection is split into chunks of `opts.ChunkSize` items.
+func PartitionByWithOptions[T any, K comparable, Slice ~[]T](collection Slice, iteratee func(item T) K, opts Options) []Slice {
+	keys := MapWithOptions(collection, func(item T, _ int) K {
+		return iteratee(item)
+	}, opts)
+
+	return partitionByKeys(collection, keys)
+}
+
+func partitionByKeys[T any, K comparable, Slice ~[]T](collection Slice, keys []K) []Slice {
+	result := []Slice{}
+	seen := map[K]int{}
+
 	for i := range collection {
 		resultIndex, ok := seen[keys[i]]
 		if ok {
@@ -112,3 +276,205 @@ func PartitionBy[T any, K comparable, Slice ~[]T](collection Slice, iteratee fun
 
 	return result
 }
+
+// Filter iterates over elements of collection, returning a slice of all elements predicate returns true for.
+// `predicate` is called in parallel by at most runtime.GOMAXPROCS(0) goroutines. Result keep the same order.
+func Filter[T any, Slice ~[]T](collection Slice, predicate func(item T, index int) bool) Slice {
+	keep := MapWithOptions(collection, predicate, Options{})
+
+	result := make(Slice, 0, len(collection))
+
+	for i := range collection {
+		if keep[i] {
+			result = append(result, collection[i])
+		}
+	}
+
+	return result
+}
+
+// Reject is the opposite of Filter, this method returns the elements of collection that predicate does not return true for.
+// `predicate` is called in parallel by at most runtime.GOMAXPROCS(0) goroutines. Result keep the same order.
+func Reject[T any, Slice ~[]T](collection Slice, predicate func(item T, index int) bool) Slice {
+	return Filter(collection, func(item T, index int) bool {
+		return !predicate(item, index)
+	})
+}
+
+// Reduce reduces collection to a value which is the accumulated result of running each element in collection
+// through accumulator. The collection is split into chunks reduced in parallel, starting from `initial`,
+// then the partial results are merged pairwise by `combiner`, preserving their order.
+// `combiner` must be associative and `initial` must be its identity value (eg: 0 for a sum),
+// so that the result is the same as lo.Reduce.
+func Reduce[T, R any](collection []T, accumulator func(agg R, item T, index int) R, combiner func(a, b R) R, initial R) R {
+	if len(collection) == 0 {
+		return initial
+	}
+
+	_, chunkSize := Options{}.normalize(len(collection))
+
+	partials := make([]R, (len(collection)+chunkSize-1)/chunkSize)
+
+	forEachChunk(len(collection), Options{ChunkSize: chunkSize}, func(start, end int) {
+		agg := initial
+		for i := start; i < end; i++ {
+			agg = accumulator(agg, collection[i], i)
+		}
+		partials[start/chunkSize] = agg
+	})
+
+	for len(partials) > 1 {
+		next := make([]R, (len(partials)+1)/2)
+
+		forEachChunk(len(next), Options{ChunkSize: 1}, func(start, end int) {
+			for i := start; i < end; i++ {
+				if 2*i+1 < len(partials) {
+					next[i] = combiner(partials[2*i], partials[2*i+1])
+				} else {
+					next[i] = partials[2*i]
+				}
+			}
+		})
+
+		partials = next
+	}
+
+	return partials[0]
+}
+
+// UniqBy returns a duplicate-free version of a slice, in which only the first occurrence of each element is kept.
+// `iteratee` is called in parallel by at most runtime.GOMAXPROCS(0) goroutines. Result keep the same order.
+func UniqBy[T any, U comparable, Slice ~[]T](collection Slice, iteratee func(item T) U) Slice {
+	keys := MapWithOptions(collection, func(item T, _ int) U {
+		return iteratee(item)
+	}, Options{})
+
+	result := make(Slice, 0, len(collection))
+	seen := make(map[U]struct{}, len(collection))
+
+	for i := range collection {
+		if _, ok := seen[keys[i]]; ok {
+			continue
+		}
+
+		seen[keys[i]] = struct{}{}
+		result = append(result, collection[i])
+	}
+
+	return result
+}
+
+// KeyBy transforms a slice or a slice of structs to a map based on a pivot callback.
+// When several items share the same key, the last one is kept.
+// `iteratee` is called in parallel by at most runtime.GOMAXPROCS(0) goroutines.
+func KeyBy[K comparable, V any](collection []V, iteratee func(item V) K) map[K]V {
+	keys := MapWithOptions(collection, func(item V, _ int) K {
+		return iteratee(item)
+	}, Options{})
+
+	result := make(map[K]V, len(collection))
+
+	for i := range collection {
+		result[keys[i]] = collection[i]
+	}
+
+	return result
+}
+
+// CountBy counts the number of elements in the collection for which predicate is true.
+// `predicate` is called in parallel by at most runtime.GOMAXPROCS(0) goroutines.
+func CountBy[T any](collection []T, predicate func(item T) bool) int {
+	var count int64
+
+	forEachChunk(len(collection), Options{}, func(start, end int) {
+		var c int64
+		for i := start; i < end; i++ {
+			if predicate(collection[i]) {
+				c++
+			}
+		}
+		atomic.AddInt64(&count, c)
+	})
+
+	return int(count)
+}
+
+// SortBy returns a copy of the collection sorted in ascending order of the keys returned by iteratee.
+// The sort is stable: items with equal keys keep their original order.
+// `iteratee` is called in parallel, then chunks are sorted in parallel and merged pairwise.
+func SortBy[T any, K constraints.Ordered, Slice ~[]T](collection Slice, iteratee func(item T) K) Slice {
+	size := len(collection)
+
+	keys := MapWithOptions(collection, func(item T, _ int) K {
+		return iteratee(item)
+	}, Options{})
+
+	// sort the indexes instead of the items, so that keys are computed once
+	indexes := make([]int, size)
+	for i := range indexes {
+		indexes[i] = i
+	}
+
+	less := func(a, b int) bool {
+		return keys[a] < keys[b] || (keys[a] == keys[b] && a < b)
+	}
+
+	_, chunkSize := Options{}.normalize(size)
+
+	forEachChunk(size, Options{ChunkSize: chunkSize}, func(start, end int) {
+		part := indexes[start:end]
+		sort.Slice(part, func(i, j int) bool {
+			return less(part[i], part[j])
+		})
+	})
+
+	buffer := make([]int, size)
+
+	for width := chunkSize; width < size; width *= 2 {
+		pairs := (size + 2*width - 1) / (2 * width)
+
+		forEachChunk(pairs, Options{ChunkSize: 1}, func(start, end int) {
+			for p := start; p < end; p++ {
+				low := p * 2 * width
+				mid := low + width
+				high := mid + width
+				if mid > size {
+					mid = size
+				}
+				if high > size {
+					high = size
+				}
+
+				mergeSorted(buffer[low:high], indexes[low:mid], indexes[mid:high], less)
+			}
+		})
+
+		indexes, buffer = buffer, indexes
+	}
+
+	result := make(Slice, size)
+	for i := range indexes {
+		result[i] = collection[indexes[i]]
+	}
+
+	return result
+}
+
+// mergeSorted merges 2 sorted slices into dst.
+func mergeSorted(dst, left, right []int, less func(a, b int) bool) {
+	i, j, k := 0, 0, 0
+
+	for i < len(left) && j < len(right) {
+		if less(right[j], left[i]) {
+			dst[k] = right[j]
+			j++
+		} else {
+			dst[k] = left[i]
+			i++
+		}
+		k++
+	}
+
+	k += copy(dst[k:], left[i:])
+	copy(dst[k:], right[j:])
+}
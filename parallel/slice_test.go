@@ -1,11 +1,14 @@
 package parallel
 
 import (
+	"context"
+	"errors"
 	"sort"
 	"strconv"
 	"sync/atomic"
 	"testing"
 
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -33,6 +36,160 @@ func TestMap(t *testing.T) {
 	})
 }
 
+func TestMapWithOptions(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	collection := make([]int, 10_000)
+	for i := range collection {
+		collection[i] = i
+	}
+
+	result := MapWithOptions(collection, func(x, i int) string {
+		return strconv.Itoa(x * i)
+	}, Options{Concurrency: 4, ChunkSize: 128})
+
+	is.Len(result, len(collection))
+	for i := range collection {
+		is.Equal(strconv.Itoa(i*i), result[i])
+	}
+
+	is.Empty(MapWithOptions([]int{}, func(x, _ int) int { return x }, Options{}))
+}
+
+func TestMapErr(t *testing.T) {
+	t.Parallel()
+
+	t.Run("transforms every item", func(t *testing.T) {
+		t.Parallel()
+		is := assert.New(t)
+
+		result, err := MapErr([]int{1, 2, 3, 4}, func(x, _ int) (string, error) {
+			return strconv.Itoa(x), nil
+		})
+		is.NoError(err)
+		is.Equal([]string{"1", "2", "3", "4"}, result)
+	})
+
+	t.Run("returns the error", func(t *testing.T) {
+		t.Parallel()
+		is := assert.New(t)
+
+		result, err := MapErr([]int{1, 2, 3, 4}, func(x, _ int) (string, error) {
+			if x == 3 {
+				return "", errors.New("number 3 is not allowed")
+			}
+			return strconv.Itoa(x), nil
+		})
+		is.EqualError(err, "number 3 is not allowed")
+		is.Nil(result)
+	})
+
+	t.Run("recovers panics", func(t *testing.T) {
+		t.Parallel()
+		is := assert.New(t)
+
+		errBoom := errors.New("boom")
+
+		result, err := MapErr([]int{1, 2}, func(x, _ int) (int, error) {
+			if x == 2 {
+				panic(errBoom)
+			}
+			return x, nil
+		})
+		is.ErrorIs(err, errBoom)
+		is.Nil(result)
+
+		_, err = MapErr([]int{1}, func(x, _ int) (int, error) {
+			panic("oops")
+		})
+		is.EqualError(err, "parallel: recovered from panic: oops")
+	})
+}
+
+func TestMapErrWithContext(t *testing.T) {
+	t.Parallel()
+
+	t.Run("stops scheduling after the first error", func(t *testing.T) {
+		t.Parallel()
+		is := assert.New(t)
+
+		var calls int64
+		collection := make([]int, 1000)
+
+		_, err := MapErrWithContext(context.Background(), collection, func(ctx context.Context, _, i int) (int, error) {
+			atomic.AddInt64(&calls, 1)
+			if i == 10 {
+				return 0, errors.New("failed")
+			}
+			return i, nil
+		}, Options{Concurrency: 1, ChunkSize: 1})
+		is.EqualError(err, "failed")
+		is.Equal(int64(11), atomic.LoadInt64(&calls))
+	})
+
+	t.Run("joins errors", func(t *testing.T) {
+		t.Parallel()
+		is := assert.New(t)
+
+		err1 := errors.New("error 1")
+		err3 := errors.New("error 3")
+
+		var calls int64
+		result, err := MapErrWithContext(context.Background(), []int{0, 1, 2, 3, 4}, func(ctx context.Context, x, _ int) (int, error) {
+			atomic.AddInt64(&calls, 1)
+			switch x {
+			case 1:
+				return 0, err1
+			case 3:
+				return 0, err3
+			}
+			return x, nil
+		}, Options{Concurrency: 2, JoinErrors: true})
+		is.Nil(result)
+		is.EqualError(err, "error 1\nerror 3")
+		is.Equal(int64(5), atomic.LoadInt64(&calls))
+	})
+
+	t.Run("canceled context", func(t *testing.T) {
+		t.Parallel()
+		is := assert.New(t)
+
+		ctx, cancel := context.WithCancel(context.Background())
+
+		var calls int64
+		result, err := MapErrWithContext(ctx, make([]int, 100), func(ctx context.Context, _, i int) (int, error) {
+			if atomic.AddInt64(&calls, 1) == 5 {
+				cancel()
+			}
+			return i, nil
+		}, Options{Concurrency: 1, ChunkSize: 1})
+		is.ErrorIs(err, context.Canceled)
+		is.Nil(result)
+		is.Equal(int64(5), atomic.LoadInt64(&calls))
+
+		result, err = MapErrWithContext(ctx, []int{1}, func(ctx context.Context, x, _ int) (int, error) {
+			return x, nil
+		}, Options{})
+		is.ErrorIs(err, context.Canceled)
+		is.Nil(result)
+	})
+
+	t.Run("cancels the context passed to callbacks", func(t *testing.T) {
+		t.Parallel()
+		is := assert.New(t)
+
+		_, err := MapErrWithContext(context.Background(), []int{0, 1}, func(ctx context.Context, x, _ int) (int, error) {
+			if x == 0 {
+				return 0, errors.New("failed")
+			}
+			<-ctx.Done()
+			return x, nil
+		}, Options{Concurrency: 2, ChunkSize: 1})
+		is.EqualError(err, "failed")
+	})
+}
+
 func TestForEach(t *testing.T) {
 	t.Parallel()
 	is := assert.New(t)
@@ -46,6 +203,45 @@ func TestForEach(t *testing.T) {
 	is.Equal(uint64(4), atomic.LoadUint64(&counter))
 }
 
+func TestForEachWithOptions(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	var counter uint64
+	collection := []int{1, 2, 3, 4}
+	ForEachWithOptions(collection, func(x, i int) {
+		atomic.AddUint64(&counter, uint64(x))
+	}, Options{Concurrency: 2})
+
+	is.Equal(uint64(10), atomic.LoadUint64(&counter))
+}
+
+func TestForEachErr(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	var counter uint64
+	err := ForEachErr([]int{1, 2, 3, 4}, func(x, _ int) error {
+		atomic.AddUint64(&counter, uint64(x))
+		return nil
+	})
+	is.NoError(err)
+	is.Equal(uint64(10), atomic.LoadUint64(&counter))
+
+	err = ForEachErr([]int{1, 2, 3, 4}, func(x, _ int) error {
+		if x == 2 {
+			return errors.New("failed")
+		}
+		return nil
+	})
+	is.EqualError(err, "failed")
+
+	err = ForEachErrWithContext(context.Background(), []int{1, 2, 3, 4}, func(_ context.Context, x, _ int) error {
+		return errors.New(strconv.Itoa(x))
+	}, Options{JoinErrors: true})
+	is.EqualError(err, "1\n2\n3\n4")
+}
+
 func TestTimes(t *testing.T) {
 	t.Parallel()
 	is := assert.New(t)
@@ -57,6 +253,46 @@ func TestTimes(t *testing.T) {
 	is.Equal([]string{"0", "1", "2"}, result1)
 }
 
+func TestTimesWithOptions(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	result1 := TimesWithOptions(5, func(i int) string {
+		return strconv.FormatInt(int64(i), 10)
+	}, Options{Concurrency: 2, ChunkSize: 2})
+	result2 := TimesWithOptions(-1, func(i int) string {
+		return ""
+	}, Options{})
+
+	is.Equal([]string{"0", "1", "2", "3", "4"}, result1)
+	is.Empty(result2)
+}
+
+func TestTimesErr(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	result1, err1 := TimesErr(3, func(i int) (string, error) {
+		return strconv.Itoa(i), nil
+	})
+	result2, err2 := TimesErr(3, func(i int) (string, error) {
+		if i == 1 {
+			return "", errors.New("failed")
+		}
+		return strconv.Itoa(i), nil
+	})
+	result3, err3 := TimesErrWithContext(context.Background(), 0, func(_ context.Context, i int) (string, error) {
+		return "", errors.New("never called")
+	}, Options{})
+
+	is.NoError(err1)
+	is.Equal([]string{"0", "1", "2"}, result1)
+	is.EqualError(err2, "failed")
+	is.Nil(result2)
+	is.NoError(err3)
+	is.Empty(result3)
+}
+
 func TestGroupBy(t *testing.T) {
 	t.Parallel()
 
@@ -93,6 +329,28 @@ func TestGroupBy(t *testing.T) {
 	})
 }
 
+func TestGroupByWithOptions(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	result := GroupByWithOptions([]int{0, 1, 2, 3, 4, 5}, func(i int) int {
+		return i % 3
+	}, Options{Concurrency: 2, ChunkSize: 1})
+
+	is.Equal(map[int][]int{
+		0: {0, 3},
+		1: {1, 4},
+		2: {2, 5},
+	}, result)
+
+	type myStrings []string
+	allStrings := myStrings{"", "foo", "bar"}
+	nonempty := GroupByWithOptions(allStrings, func(i string) int {
+		return 42
+	}, Options{})
+	is.IsType(nonempty[42], allStrings, "type preserved")
+}
+
 func TestPartitionBy(t *testing.T) {
 	t.Parallel()
 
@@ -142,3 +400,198 @@ func TestPartitionBy(t *testing.T) {
 		is.IsType(nonempty[0], allStrings, "type preserved")
 	})
 }
+
+func TestPartitionByWithOptions(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	oddEven := func(x int) string {
+		if x < 0 {
+			return "negative"
+		} else if x%2 == 0 {
+			return "even"
+		}
+		return "odd"
+	}
+
+	result1 := PartitionByWithOptions([]int{-2, -1, 0, 1, 2, 3, 4, 5}, oddEven, Options{Concurrency: 3, ChunkSize: 2})
+	result2 := PartitionByWithOptions([]int{}, oddEven, Options{})
+
+	is.Equal([][]int{{-2, -1}, {0, 2, 4}, {1, 3, 5}}, result1)
+	is.Empty(result2)
+}
+
+func TestFilter(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	collection := make([]int, 10_000)
+	for i := range collection {
+		collection[i] = (i * 7919) % 1000
+	}
+
+	isEven := func(x, _ int) bool {
+		return x%2 == 0
+	}
+
+	is.Equal(lo.Filter(collection, isEven), Filter(collection, isEven))
+	is.Equal([]int{2, 4}, Filter([]int{1, 2, 3, 4}, isEven))
+	is.Empty(Filter([]int{}, isEven))
+
+	type myStrings []string
+	allStrings := myStrings{"", "foo", "bar"}
+	nonempty := Filter(allStrings, func(x string, _ int) bool {
+		return len(x) > 0
+	})
+	is.IsType(nonempty, allStrings, "type preserved")
+}
+
+func TestReject(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	collection := make([]int, 10_000)
+	for i := range collection {
+		collection[i] = (i * 7919) % 1000
+	}
+
+	isEven := func(x, _ int) bool {
+		return x%2 == 0
+	}
+
+	is.Equal(lo.Reject(collection, isEven), Reject(collection, isEven))
+	is.Equal([]int{1, 3}, Reject([]int{1, 2, 3, 4}, isEven))
+}
+
+func TestReduce(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	collection := make([]int, 10_001)
+	for i := range collection {
+		collection[i] = i
+	}
+
+	sum := func(agg, item, _ int) int {
+		return agg + item
+	}
+	add := func(a, b int) int {
+		return a + b
+	}
+
+	is.Equal(lo.Reduce(collection, sum, 0), Reduce(collection, sum, add, 0))
+	is.Equal(42, Reduce([]int{}, sum, add, 42))
+
+	// associative but not commutative
+	concat := func(agg string, item, _ int) string {
+		return agg + strconv.Itoa(item%10)
+	}
+	join := func(a, b string) string {
+		return a + b
+	}
+
+	is.Equal(lo.Reduce(collection, concat, ""), Reduce(collection, concat, join, ""))
+}
+
+func TestUniqBy(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	collection := make([]int, 10_000)
+	for i := range collection {
+		collection[i] = (i * 7919) % 1000
+	}
+
+	mod := func(x int) int {
+		return x % 37
+	}
+
+	is.Equal(lo.UniqBy(collection, mod), UniqBy(collection, mod))
+	is.Equal([]int{0, 1, 2}, UniqBy([]int{0, 1, 2, 3, 4, 5}, func(i int) int {
+		return i % 3
+	}))
+
+	type myStrings []string
+	allStrings := myStrings{"", "foo", "bar"}
+	nonempty := UniqBy(allStrings, func(i string) string {
+		return i
+	})
+	is.IsType(nonempty, allStrings, "type preserved")
+}
+
+func TestKeyBy(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	collection := make([]int, 10_000)
+	for i := range collection {
+		collection[i] = (i * 7919) % 1000
+	}
+
+	mod := func(x int) int {
+		return x % 37
+	}
+
+	is.Equal(lo.KeyBy(collection, mod), KeyBy(collection, mod))
+	is.Equal(map[int]string{1: "a", 2: "aa", 3: "aaa"}, KeyBy([]string{"a", "aa", "aaa"}, func(str string) int {
+		return len(str)
+	}))
+}
+
+func TestCountBy(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	collection := make([]int, 10_000)
+	for i := range collection {
+		collection[i] = (i * 7919) % 1000
+	}
+
+	isEven := func(x int) bool {
+		return x%2 == 0
+	}
+
+	is.Equal(lo.CountBy(collection, isEven), CountBy(collection, isEven))
+	is.Equal(2, CountBy([]int{1, 5, 1}, func(i int) bool {
+		return i < 4
+	}))
+	is.Zero(CountBy([]int{}, isEven))
+}
+
+func TestSortBy(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	collection := make([]int, 10_000)
+	for i := range collection {
+		collection[i] = (i * 7919) % 1000
+	}
+
+	mod := func(x int) int {
+		return x % 37
+	}
+
+	expected := append([]int{}, collection...)
+	sort.SliceStable(expected, func(i, j int) bool {
+		return mod(expected[i]) < mod(expected[j])
+	})
+
+	result := SortBy(collection, mod)
+	is.Equal(expected, result)
+	is.True(lo.IsSortedBy(result, mod))
+	is.Equal(0, collection[0], "input not mutated")
+	is.Equal(919, collection[1], "input not mutated")
+
+	is.Equal([]string{"a", "bb", "cc", "ddd"}, SortBy([]string{"ddd", "bb", "a", "cc"}, func(s string) int {
+		return len(s)
+	}))
+	is.Empty(SortBy([]int{}, mod))
+
+	type myStrings []string
+	allStrings := myStrings{"", "foo", "bar"}
+	sorted := SortBy(allStrings, func(s string) string {
+		return s
+	})
+	is.IsType(sorted, allStrings, "type preserved")
+	is.Equal(myStrings{"", "bar", "foo"}, sorted)
+}
@@ -0,0 +1,134 @@
+package lo
+
+// PriorityQueue is a binary heap of items ordered by a comparison function.
+// Pop and Peek return the item for which `less` is true against every other item
+// (ie: the smallest one), so a max-queue is built by passing a "greater" function.
+// A PriorityQueue is not safe for concurrent use.
+type PriorityQueue[T any] struct {
+	items []T
+	less  func(a, b T) bool
+}
+
+// NewPriorityQueue creates a PriorityQueue ordered by `less`, containing the given items.
+// Building a queue from n items is O(n).
+func NewPriorityQueue[T any](less func(a, b T) bool, items ...T) *PriorityQueue[T] {
+	pq := &PriorityQueue[T]{
+		items: append(make([]T, 0, len(items)), items...),
+		less:  less,
+	}
+
+	for i := len(pq.items)/2 - 1; i >= 0; i-- {
+		pq.down(i)
+	}
+
+	return pq
+}
+
+// Push adds items to the queue. The complexity is O(log n) per item.
+func (pq *PriorityQueue[T]) Push(items ...T) {
+	for i := range items {
+		pq.items = append(pq.items, items[i])
+		pq.up(len(pq.items) - 1)
+	}
+}
+
+// Pop removes and returns the smallest item. Returns false when the queue is empty.
+// The complexity is O(log n).
+func (pq *PriorityQueue[T]) Pop() (T, bool) {
+	var zero T
+
+	n := len(pq.items) - 1
+	if n < 0 {
+		return zero, false
+	}
+
+	item := pq.items[0]
+	pq.items[0] = pq.items[n]
+	pq.items[n] = zero // release the reference for the garbage collector
+	pq.items = pq.items[:n]
+	pq.down(0)
+
+	return item, true
+}
+
+// Peek returns the smallest item without removing it. Returns false when the queue is empty.
+func (pq *PriorityQueue[T]) Peek() (T, bool) {
+	if len(pq.items) == 0 {
+		var zero T
+		return zero, false
+	}
+
+	return pq.items[0], true
+}
+
+// PushPop adds an item and removes the smallest item, more efficiently than Push followed by Pop.
+func (pq *PriorityQueue[T]) PushPop(item T) T {
+	if len(pq.items) == 0 || !pq.less(pq.items[0], item) {
+		return item
+	}
+
+	item, pq.items[0] = pq.items[0], item
+	pq.down(0)
+
+	return item
+}
+
+// Len returns the number of items in the queue.
+func (pq *PriorityQueue[T]) Len() int {
+	return len(pq.items)
+}
+
+// Clear removes every item from the queue.
+func (pq *PriorityQueue[T]) Clear() {
+	var zero T
+	for i := range pq.items {
+		pq.items[i] = zero
+	}
+
+	pq.items = pq.items[:0]
+}
+
+// PopAll removes every item from the queue and returns them from the smallest to the largest.
+func (pq *PriorityQueue[T]) PopAll() []T {
+	result := make([]T, len(pq.items))
+
+	for i := range result {
+		result[i], _ = pq.Pop()
+	}
+
+	return result
+}
+
+func (pq *PriorityQueue[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !pq.less(pq.items[i], pq.items[parent]) {
+			break
+		}
+
+		pq.items[i], pq.items[parent] = pq.items[parent], pq.items[i]
+		i = parent
+	}
+}
+
+func (pq *PriorityQueue[T]) down(i int) {
+	n := len(pq.items)
+
+	for {
+		smallest := i
+		left, right := 2*i+1, 2*i+2
+
+		if left < n && pq.less(pq.items[left], pq.items[smallest]) {
+			smallest = left
+		}
+		if right < n && pq.less(pq.items[right], pq.items[smallest]) {
+			smallest = right
+		}
+		if smallest == i {
+			return
+		}
+
+		pq.items[i], pq.items[smallest] = pq.items[smallest], pq.items[i]
+		i = smallest
+	}
+}
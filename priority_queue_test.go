@@ -0,0 +1,110 @@
+package lo
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPriorityQueue(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	pq := NewPriorityQueue(func(a, b int) bool {
+		return a < b
+	}, 5, 3, 8)
+	pq.Push(1, 9, 4)
+
+	is.Equal(6, pq.Len())
+
+	head, ok := pq.Peek()
+	is.True(ok)
+	is.Equal(1, head)
+	is.Equal(6, pq.Len())
+
+	item, ok := pq.Pop()
+	is.True(ok)
+	is.Equal(1, item)
+
+	is.Equal([]int{3, 4, 5, 8, 9}, pq.PopAll())
+	is.Zero(pq.Len())
+
+	item, ok = pq.Pop()
+	is.False(ok)
+	is.Zero(item)
+
+	item, ok = pq.Peek()
+	is.False(ok)
+	is.Zero(item)
+}
+
+func TestPriorityQueueMaxHeap(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	type task struct {
+		name     string
+		priority int
+	}
+
+	pq := NewPriorityQueue(func(a, b task) bool {
+		return a.priority > b.priority
+	})
+	pq.Push(task{"low", 1}, task{"high", 10}, task{"medium", 5})
+
+	first, _ := pq.Pop()
+	second, _ := pq.Pop()
+	is.Equal("high", first.name)
+	is.Equal("medium", second.name)
+}
+
+func TestPriorityQueuePushPop(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	less := func(a, b int) bool {
+		return a < b
+	}
+
+	pq := NewPriorityQueue(less, 3, 5, 7)
+	is.Equal(1, pq.PushPop(1))
+	is.Equal(3, pq.PushPop(6))
+	is.Equal([]int{5, 6, 7}, pq.PopAll())
+
+	empty := NewPriorityQueue(less)
+	is.Equal(42, empty.PushPop(42))
+	is.Zero(empty.Len())
+}
+
+func TestPriorityQueueClear(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	pq := NewPriorityQueue(func(a, b string) bool {
+		return a < b
+	}, "b", "a")
+	pq.Clear()
+	is.Zero(pq.Len())
+
+	pq.Push("c")
+	is.Equal([]string{"c"}, pq.PopAll())
+}
+
+func TestPriorityQueueSorts(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	input := make([]int, 1000)
+	for i := range input {
+		input[i] = (i * 7919) % 1009
+	}
+
+	pq := NewPriorityQueue(func(a, b int) bool {
+		return a < b
+	}, input...)
+
+	expected := append([]int{}, input...)
+	sort.Ints(expected)
+	is.Equal(expected, pq.PopAll())
+}
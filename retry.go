@@ -1,135 +1,291 @@
 package lo
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
 	"sync"
 	"time"
 
+	"github.com/samber/lo/internal/xerrors"
+	"github.com/samber/lo/internal/xrand"
 	"github.com/samber/lo/internal/xtime"
 )
 
-type debounce struct {
+// DebounceOptions configures NewDebounceWithOptions and NewDebounceByWithOptions.
+type DebounceOptions struct {
+	// Leading invokes the callbacks on the first call of a burst, instead of waiting for the burst to end.
+	Leading bool
+	// Trailing invokes the callbacks once the burst ended, if calls happened since the last invocation.
+	// It is enabled by default, unless Leading is set.
+	Trailing bool
+	// MaxWait is the maximum duration the pending calls may be delayed. Disabled when lower than or equal to 0.
+	MaxWait time.Duration
+}
+
+func (o DebounceOptions) trailing() bool {
+	return o.Trailing || !o.Leading
+}
+
+type debounce[T any] struct {
 	after     time.Duration
-	mu        *sync.Mutex
-	timer     *time.Timer
-	done      bool
-	callbacks []func()
+	options   DebounceOptions
+	callbacks []func(value T, count int)
+
+	mu       sync.Mutex
+	timer    *time.Timer
+	maxTimer *time.Timer
+	done     bool
+
+	// generations are incremented every time the timers are reset or stopped, so that a timer
+	// that already fired and is waiting for the mutex does not invoke the callbacks
+	generation    uint64
+	maxGeneration uint64
+
+	// latest value and number of calls since the last invocation
+	value T
+	count int
 }
 
-func (d *debounce) reset() {
+func (d *debounce[T]) call(value T) {
 	d.mu.Lock()
-	defer d.mu.Unlock()
 
 	if d.done {
+		d.mu.Unlock()
 		return
 	}
 
-	if d.timer != nil {
+	d.value = value
+	d.count++
+
+	var invoke func()
+
+	if d.timer == nil {
+		// first call of a burst
+		if d.options.Leading {
+			invoke = d.take()
+		}
+
+		if d.options.MaxWait > 0 {
+			d.startMaxWait()
+		}
+	} else {
 		d.timer.Stop()
 	}
 
+	d.generation++
+	generation := d.generation
 	d.timer = time.AfterFunc(d.after, func() {
-		// We need to lock the mutex here to avoid race conditions with 2 concurrent calls to reset()
-		d.mu.Lock()
-		callbacks := append([]func(){}, d.callbacks...)
-		d.mu.Unlock()
+		d.trailingEdge(generation)
+	})
 
-		for i := range callbacks {
-			callbacks[i]()
-		}
+	d.mu.Unlock()
+
+	if invoke != nil {
+		invoke()
+	}
+}
+
+func (d *debounce[T]) startMaxWait() {
+	d.maxGeneration++
+	generation := d.maxGeneration
+	d.maxTimer = time.AfterFunc(d.options.MaxWait, func() {
+		d.maxWaitEdge(generation)
 	})
 }
 
-func (d *debounce) cancel() {
+func (d *debounce[T]) trailingEdge(generation uint64) {
 	d.mu.Lock()
-	defer d.mu.Unlock()
 
+	if d.done || generation != d.generation {
+		d.mu.Unlock()
+		return
+	}
+
+	invoke := d.end()
+
+	d.mu.Unlock()
+
+	if invoke != nil {
+		invoke()
+	}
+}
+
+func (d *debounce[T]) maxWaitEdge(generation uint64) {
+	d.mu.Lock()
+
+	if d.done || generation != d.maxGeneration {
+		d.mu.Unlock()
+		return
+	}
+
+	invoke := d.take()
+	d.startMaxWait()
+
+	d.mu.Unlock()
+
+	if invoke != nil {
+		invoke()
+	}
+}
+
+// end stops the timers and returns the trailing invocation, if any.
+func (d *debounce[T]) end() func() {
+	d.stop()
+
+	if d.options.trailing() {
+		return d.take()
+	}
+
+	d.take()
+	return nil
+}
+
+func (d *debounce[T]) stop() {
 	if d.timer != nil {
 		d.timer.Stop()
 		d.timer = nil
 	}
 
+	if d.maxTimer != nil {
+		d.maxTimer.Stop()
+		d.maxTimer = nil
+	}
+
+	d.generation++
+	d.maxGeneration++
+}
+
+// take resets the pending calls and returns a function invoking the callbacks with them,
+// or nil when there is no pending call.
+func (d *debounce[T]) take() func() {
+	if d.count == 0 {
+		return nil
+	}
+
+	value, count := d.value, d.count
+	callbacks := d.callbacks
+
+	var zero T
+	d.value = zero
+	d.count = 0
+
+	return func() {
+		for i := range callbacks {
+			callbacks[i](value, count)
+		}
+	}
+}
+
+func (d *debounce[T]) flush() {
+	d.mu.Lock()
+
+	if d.done || d.timer == nil {
+		d.mu.Unlock()
+		return
+	}
+
+	invoke := d.end()
+
+	d.mu.Unlock()
+
+	if invoke != nil {
+		invoke()
+	}
+}
+
+func (d *debounce[T]) cancel() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.stop()
+	d.take()
 	d.done = true
 }
 
 // NewDebounce creates a debounced instance that delays invoking functions given until after wait milliseconds have elapsed.
 // Play: https://go.dev/play/p/_IPY7ROzbMk
 func NewDebounce(duration time.Duration, f ...func()) (func(), func()) {
-	d := &debounce{
-		after:     duration,
-		mu:        new(sync.Mutex),
-		timer:     nil,
-		done:      false,
-		callbacks: f,
-	}
+	callbacks := Map(f, func(callback func(), _ int) func(struct{}, int) {
+		return func(struct{}, int) {
+			callback()
+		}
+	})
+
+	debounce, cancel, _ := NewDebounceWithOptions(duration, DebounceOptions{}, callbacks...)
 
 	return func() {
-		d.reset()
-	}, d.cancel
+		debounce(struct{}{})
+	}, cancel
 }
 
-type debounceByItem struct {
-	mu    *sync.Mutex
-	timer *time.Timer
-	count int
+// NewDebounceWithOptions creates a debounced instance that delays invoking functions given until after wait milliseconds
+// have elapsed since the last call. The functions receive the latest value and the number of calls since the last invocation.
+// The options enable the invocation on the leading edge, on the trailing edge or both, and bound the delay with MaxWait.
+// It returns the debounced function, a function canceling the pending calls and stopping the debounce, and a function
+// invoking the pending calls right away.
+func NewDebounceWithOptions[T any](duration time.Duration, options DebounceOptions, f ...func(value T, count int)) (func(value T), func(), func()) {
+	d := newDebounce(duration, options, f)
+
+	return d.call, d.cancel, d.flush
+}
+
+func newDebounce[T any](duration time.Duration, options DebounceOptions, callbacks []func(value T, count int)) *debounce[T] {
+	return &debounce[T]{
+		after:     duration,
+		options:   options,
+		callbacks: callbacks,
+	}
 }
 
-type debounceBy[T comparable] struct {
+type debounceBy[K comparable, T any] struct {
 	after     time.Duration
-	mu        *sync.Mutex
-	items     map[T]*debounceByItem
-	callbacks []func(key T, count int)
+	options   DebounceOptions
+	mu        sync.Mutex
+	items     map[K]*debounce[T]
+	callbacks []func(key K, value T, count int)
 }
 
-func (d *debounceBy[T]) reset(key T) {
+func (d *debounceBy[K, T]) item(key K) *debounce[T] {
 	d.mu.Lock()
-	if _, ok := d.items[key]; !ok {
-		d.items[key] = &debounceByItem{
-			mu:    new(sync.Mutex),
-			timer: nil,
-		}
-	}
+	defer d.mu.Unlock()
 
-	item := d.items[key]
+	item, ok := d.items[key]
+	if !ok {
+		item = newDebounce(d.after, d.options, []func(value T, count int){
+			func(value T, count int) {
+				for i := range d.callbacks {
+					d.callbacks[i](key, value, count)
+				}
+			},
+		})
+		d.items[key] = item
+	}
 
-	d.mu.Unlock()
+	return item
+}
 
-	item.mu.Lock()
-	defer item.mu.Unlock()
+func (d *debounceBy[K, T]) call(key K, value T) {
+	d.item(key).call(value)
+}
 
-	item.count++
+func (d *debounceBy[K, T]) flush(key K) {
+	d.mu.Lock()
+	item, ok := d.items[key]
+	d.mu.Unlock()
 
-	if item.timer != nil {
-		item.timer.Stop()
+	if ok {
+		item.flush()
 	}
-
-	item.timer = time.AfterFunc(d.after, func() {
-		// We need to lock the mutex here to avoid race conditions with 2 concurrent calls to reset()
-		item.mu.Lock()
-		count := item.count
-		item.count = 0
-		callbacks := append([]func(key T, count int){}, d.callbacks...)
-		item.mu.Unlock()
-
-		for i := range callbacks {
-			callbacks[i](key, count)
-		}
-	})
 }
 
-func (d *debounceBy[T]) cancel(key T) {
+func (d *debounceBy[K, T]) cancel(key K) {
 	d.mu.Lock()
 	defer d.mu.Unlock()
 
 	if item, ok := d.items[key]; ok {
-		item.mu.Lock()
-
-		if item.timer != nil {
-			item.timer.Stop()
-			item.timer = nil
-		}
-
-		item.mu.Unlock()
-
+		item.cancel()
 		delete(d.items, key)
 	}
 }
@@ -137,16 +293,32 @@ func (d *debounceBy[T]) cancel(key T) {
 // NewDebounceBy creates a debounced instance for each distinct key, that delays invoking functions given until after wait milliseconds have elapsed.
 // Play: https://go.dev/play/p/Izk7GEzZm2Q
 func NewDebounceBy[T comparable](duration time.Duration, f ...func(key T, count int)) (func(key T), func(key T)) {
-	d := &debounceBy[T]{
+	callbacks := Map(f, func(callback func(key T, count int), _ int) func(T, struct{}, int) {
+		return func(key T, _ struct{}, count int) {
+			callback(key, count)
+		}
+	})
+
+	debounce, cancel, _ := NewDebounceByWithOptions(duration, DebounceOptions{}, callbacks...)
+
+	return func(key T) {
+		debounce(key, struct{}{})
+	}, cancel
+}
+
+// NewDebounceByWithOptions creates a debounced instance for each distinct key, like NewDebounceWithOptions.
+// The functions receive the key, its latest value and the number of calls since the last invocation for this key.
+// It returns the debounced function, a function canceling the pending calls of a key, and a function invoking
+// the pending calls of a key right away.
+func NewDebounceByWithOptions[K comparable, T any](duration time.Duration, options DebounceOptions, f ...func(key K, value T, count int)) (func(key K, value T), func(key K), func(key K)) {
+	d := &debounceBy[K, T]{
 		after:     duration,
-		mu:        new(sync.Mutex),
-		items:     map[T]*debounceByItem{},
+		options:   options,
+		items:     map[K]*debounce[T]{},
 		callbacks: f,
 	}
 
-	return func(key T) {
-		d.reset(key)
-	}, d.cancel
+	return d.call, d.cancel, d.flush
 }
 
 // Attempt invokes a function N times until it returns valid output. Returns either the caught error or nil.
@@ -245,43 +417,398 @@ func AttemptWhileWithDelay(maxIteration int, delay time.Duration, f func(int, ti
 	return maxIteration, xtime.Since(start), err
 }
 
-type transactionStep[T any] struct {
-	exec       func(T) (T, error)
-	onRollback func(T) T
+// AttemptWithContext invokes a function N times until it returns valid output,
+// or until the context is canceled. The context is passed to the function.
+// Returns the number of attempts, the elapsed time and either the caught error,
+// ctx.Err() or nil. When the second argument is less than `1`, the function runs
+// until a successful response is returned or the context is canceled.
+func AttemptWithContext(ctx context.Context, maxIteration int, f func(ctx context.Context, index int) error) (int, time.Duration, error) {
+	return AttemptWhileWithDelayWithContext(ctx, maxIteration, 0, func(ctx context.Context, index int, _ time.Duration) (error, bool) {
+		return f(ctx, index), true
+	})
+}
+
+// AttemptWithDelayWithContext invokes a function N times until it returns valid output,
+// with a pause between each call, or until the context is canceled. The pause is
+// interrupted as soon as the context is canceled. The context is passed to the function.
+// Returns the number of attempts, the elapsed time and either the caught error,
+// ctx.Err() or nil. When the second argument is less than `1`, the function runs
+// until a successful response is returned or the context is canceled.
+func AttemptWithDelayWithContext(ctx context.Context, maxIteration int, delay time.Duration, f func(ctx context.Context, index int, duration time.Duration) error) (int, time.Duration, error) {
+	return AttemptWhileWithDelayWithContext(ctx, maxIteration, delay, func(ctx context.Context, index int, duration time.Duration) (error, bool) {
+		return f(ctx, index, duration), true
+	})
+}
+
+// AttemptWhileWithContext invokes a function N times until it returns valid output,
+// or until the context is canceled. The function also returns a bool value to determine
+// whether it should be invoked again. The context is passed to the function.
+// Returns the number of attempts, the elapsed time and either the caught error,
+// ctx.Err() or nil. When the second argument is less than `1`, the function runs
+// until a successful response is returned or the context is canceled.
+func AttemptWhileWithContext(ctx context.Context, maxIteration int, f func(ctx context.Context, index int) (error, bool)) (int, time.Duration, error) {
+	return AttemptWhileWithDelayWithContext(ctx, maxIteration, 0, func(ctx context.Context, index int, _ time.Duration) (error, bool) {
+		return f(ctx, index)
+	})
+}
+
+// AttemptWhileWithDelayWithContext invokes a function N times until it returns valid output,
+// with a pause between each call, or until the context is canceled. The function also returns
+// a bool value to determine whether it should be invoked again. The pause is interrupted as
+// soon as the context is canceled. The context is passed to the function.
+// Returns the number of attempts, the elapsed time and either the caught error,
+// ctx.Err() or nil. When the second argument is less than `1`, the function runs
+// until a successful response is returned or the context is canceled.
+func AttemptWhileWithDelayWithContext(ctx context.Context, maxIteration int, delay time.Duration, f func(ctx context.Context, index int, duration time.Duration) (error, bool)) (int, time.Duration, error) {
+	var err error
+	var shouldContinueInvoke bool
+
+	start := xtime.Now()
+
+	for i := 0; maxIteration <= 0 || i < maxIteration; i++ {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return i, xtime.Since(start), ctxErr
+		}
+
+		err, shouldContinueInvoke = f(ctx, i, xtime.Since(start))
+		if !shouldContinueInvoke { // if shouldContinueInvoke is false, then return immediately
+			return i + 1, xtime.Since(start), err
+		}
+		if err == nil {
+			return i + 1, xtime.Since(start), nil
+		}
+
+		if maxIteration <= 0 || i+1 < maxIteration {
+			if ctxErr := xtime.SleepWithContext(ctx, delay); ctxErr != nil {
+				return i + 1, xtime.Since(start), ctxErr
+			}
+		}
+	}
+
+	return maxIteration, xtime.Since(start), err
+}
+
+// BackoffStrategy defines how the delay between 2 attempts grows.
+type BackoffStrategy int
+
+const (
+	// BackoffExponential multiplies the delay by RetryPolicy.Multiplier after each attempt.
+	BackoffExponential BackoffStrategy = iota
+	// BackoffConstant waits RetryPolicy.Delay between each attempt.
+	BackoffConstant
+	// BackoffDecorrelated picks a random delay between RetryPolicy.Delay and 3 times the previous delay.
+	// It is randomized by design, so RetryPolicy.Jitter is ignored.
+	BackoffDecorrelated
+)
+
+// JitterStrategy defines how the delay between 2 attempts is randomized.
+type JitterStrategy int
+
+const (
+	// JitterNone keeps the computed delay.
+	JitterNone JitterStrategy = iota
+	// JitterFull picks a random delay between 0 and the computed delay.
+	JitterFull
+	// JitterEqual picks a random delay between half of the computed delay and the computed delay.
+	JitterEqual
+)
+
+// RetryPolicy configures AttemptWithPolicy. The zero value retries forever without delay.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of calls. When lower than 1, the function
+	// runs until it succeeds, it returns a permanent error or the deadline is reached.
+	MaxAttempts int
+
+	// Delay is the pause after the first failed attempt, and the minimum pause of BackoffDecorrelated.
+	Delay time.Duration
+
+	// MaxDelay caps the pause between 2 attempts. Zero means no cap.
+	MaxDelay time.Duration
+
+	// Deadline caps the total time spent in AttemptWithPolicy: no attempt is started when
+	// waiting for it would exceed the deadline. Zero means no deadline.
+	Deadline time.Duration
+
+	// Backoff is the strategy computing the pause between 2 attempts. Defaults to BackoffExponential.
+	Backoff BackoffStrategy
+
+	// Multiplier is the growth factor of BackoffExponential. When lower than or equal to 1, it defaults to 2.
+	Multiplier float64
+
+	// Jitter randomizes the pause between 2 attempts. Defaults to JitterNone.
+	Jitter JitterStrategy
+
+	// RetryableErrors, when not empty, restricts retries to the errors matching one of them with errors.Is.
+	RetryableErrors []error
+
+	// PermanentErrors stops the retries on the errors matching one of them with errors.Is.
+	PermanentErrors []error
+
+	// RetryIf, when set, is called with every error and stops the retries when it returns false.
+	// Use errors.As in it to classify errors by type.
+	RetryIf func(err error) bool
+
+	// OnRetry, when set, is called after each failed attempt that will be retried,
+	// with the index of the failed attempt, its error and the pause before the next attempt.
+	OnRetry func(index int, err error, delay time.Duration)
+}
+
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *permanentError) Unwrap() error {
+	return e.err
+}
+
+// Permanent wraps an error to stop the retries of AttemptWithPolicy, whatever the policy.
+// AttemptWithPolicy returns the wrapped error. Returns nil when err is nil.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &permanentError{err: err}
+}
+
+// IsRetryable returns true if the policy allows to retry after this error.
+func (p RetryPolicy) IsRetryable(err error) bool {
+	var permanent *permanentError
+	if errors.As(err, &permanent) {
+		return false
+	}
+
+	for _, target := range p.PermanentErrors {
+		if errors.Is(err, target) {
+			return false
+		}
+	}
+
+	if len(p.RetryableErrors) > 0 && !ContainsBy(p.RetryableErrors, func(target error) bool {
+		return errors.Is(err, target)
+	}) {
+		return false
+	}
+
+	return p.RetryIf == nil || p.RetryIf(err)
+}
+
+// nextDelay returns the pause after the failed attempt `index`, given the previous pause.
+func (p RetryPolicy) nextDelay(index int, previous time.Duration) time.Duration {
+	var delay time.Duration
+
+	switch p.Backoff {
+	case BackoffConstant:
+		delay = p.Delay
+	case BackoffDecorrelated:
+		upper := previous * 3
+		if previous > math.MaxInt64/3 {
+			upper = math.MaxInt64
+		}
+
+		if upper <= p.Delay {
+			delay = p.Delay
+		} else {
+			delay = p.Delay + time.Duration(xrand.Int64N(int64(upper-p.Delay)))
+		}
+	default:
+		multiplier := p.Multiplier
+		if multiplier <= 1 {
+			multiplier = 2
+		}
+
+		value := float64(p.Delay) * math.Pow(multiplier, float64(index))
+		if value >= math.MaxInt64 {
+			delay = math.MaxInt64
+		} else {
+			delay = time.Duration(value)
+		}
+	}
+
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+
+	if p.Backoff == BackoffDecorrelated || delay <= 0 {
+		return delay
+	}
+
+	switch p.Jitter {
+	case JitterFull:
+		delay = time.Duration(xrand.Int64N(int64(delay) + 1))
+	case JitterEqual:
+		half := delay / 2
+		delay = half + time.Duration(xrand.Int64N(int64(delay-half)+1))
+	case JitterNone:
+	}
+
+	return delay
+}
+
+// AttemptWithPolicy invokes a function until it returns valid output, following the retry policy:
+// the pause between attempts, the maximum number of attempts, the total deadline and the errors
+// worth a retry. Errors wrapped with Permanent stop the retries immediately.
+// Returns the number of attempts, the total elapsed time and the last error, unwrapped from Permanent.
+func AttemptWithPolicy(policy RetryPolicy, f func(index int, duration time.Duration) error) (int, time.Duration, error) {
+	return AttemptWithPolicyWithContext(context.Background(), policy, func(_ context.Context, index int, duration time.Duration) error {
+		return f(index, duration)
+	})
+}
+
+// AttemptWithPolicyWithContext is like AttemptWithPolicy, but stops when the context is canceled,
+// including during a pause, and returns ctx.Err(). The context is passed to the function.
+func AttemptWithPolicyWithContext(ctx context.Context, policy RetryPolicy, f func(ctx context.Context, index int, duration time.Duration) error) (int, time.Duration, error) {
+	start := xtime.Now()
+
+	var delay time.Duration
+
+	for i := 0; ; i++ {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return i, xtime.Since(start), ctxErr
+		}
+
+		err := f(ctx, i, xtime.Since(start))
+		if err == nil {
+			return i + 1, xtime.Since(start), nil
+		}
+
+		if !policy.IsRetryable(err) {
+			return i + 1, xtime.Since(start), unwrapPermanent(err)
+		}
+
+		if policy.MaxAttempts > 0 && i+1 >= policy.MaxAttempts {
+			return i + 1, xtime.Since(start), err
+		}
+
+		delay = policy.nextDelay(i, delay)
+
+		if policy.Deadline > 0 && xtime.Since(start)+delay > policy.Deadline {
+			return i + 1, xtime.Since(start), err
+		}
+
+		if policy.OnRetry != nil {
+			policy.OnRetry(i, err, delay)
+		}
+
+		if ctxErr := xtime.SleepWithContext(ctx, delay); ctxErr != nil {
+			return i + 1, xtime.Since(start), ctxErr
+		}
+	}
+}
+
+func unwrapPermanent(err error) error {
+	if permanent, ok := err.(*permanentError); ok { //nolint:errorlint
+		return permanent.err
+	}
+
+	return err
+}
+
+// TransactionStep is a named step of a Transaction.
+type TransactionStep[T any] struct {
+	// Name identifies the step in the TransactionResult and the hooks. Defaults to "step <position>".
+	Name string
+	// Exec runs the step and returns the new state.
+	Exec func(ctx context.Context, state T) (T, error)
+	// Compensate undoes the step when a later step failed. Optional.
+	Compensate func(ctx context.Context, state T) (T, error)
+	// Retry is the optional retry policy of Exec. Each attempt receives the state given to the step.
+	Retry *RetryPolicy
+}
+
+// TransactionResult reports how a Transaction was processed.
+type TransactionResult struct {
+	// FailedStep is the name of the step that failed, or an empty string when every step succeeded.
+	FailedStep string
+	// FailedStepIndex is the index of the step that failed, or -1 when every step succeeded.
+	FailedStepIndex int
+	// Compensated lists the steps whose compensation ran, in the order they ran.
+	Compensated []string
+	// FailedCompensations lists the steps whose compensation returned an error.
+	FailedCompensations []string
 }
 
 // NewTransaction instantiate a new transaction.
 // Play: https://go.dev/play/p/7B2o52wEQbj
 func NewTransaction[T any]() *Transaction[T] {
 	return &Transaction[T]{
-		steps: []transactionStep[T]{},
+		steps: []TransactionStep[T]{},
 	}
 }
 
 // Transaction implements a Saga pattern.
 type Transaction[T any] struct {
-	steps []transactionStep[T]
+	steps  []TransactionStep[T]
+	before []func(ctx context.Context, step string, state T)
+	after  []func(ctx context.Context, step string, state T, err error)
 }
 
 // Then adds a step to the chain of callbacks. Returns the same Transaction.
 // Play: https://go.dev/play/p/Qxrd7MGQGh1 https://go.dev/play/p/xrHb2_kMvTY
 func (t *Transaction[T]) Then(exec func(T) (T, error), onRollback func(T) T) *Transaction[T] {
-	t.steps = append(t.steps, transactionStep[T]{
-		exec:       exec,
-		onRollback: onRollback,
+	return t.Step(TransactionStep[T]{
+		Exec: func(_ context.Context, state T) (T, error) {
+			return exec(state)
+		},
+		Compensate: func(_ context.Context, state T) (T, error) {
+			return onRollback(state), nil
+		},
 	})
+}
+
+// Step adds a named step, with its compensation and retry policy. Returns the same Transaction.
+func (t *Transaction[T]) Step(step TransactionStep[T]) *Transaction[T] {
+	if step.Name == "" {
+		step.Name = fmt.Sprintf("step %d", len(t.steps)+1)
+	}
+
+	t.steps = append(t.steps, step)
 
 	return t
 }
 
+// BeforeStep registers a hook called before each step. Returns the same Transaction.
+func (t *Transaction[T]) BeforeStep(hook func(ctx context.Context, step string, state T)) *Transaction[T] {
+	t.before = append(t.before, hook)
+	return t
+}
+
+// AfterStep registers a hook called after each step, with the new state and the error of the step.
+// Returns the same Transaction.
+func (t *Transaction[T]) AfterStep(hook func(ctx context.Context, step string, state T, err error)) *Transaction[T] {
+	t.after = append(t.after, hook)
+	return t
+}
+
 // Process runs the Transaction steps and rollbacks in case of errors.
 // Play: https://go.dev/play/p/Qxrd7MGQGh1 https://go.dev/play/p/xrHb2_kMvTY
 func (t *Transaction[T]) Process(state T) (T, error) {
+	state, _, err := t.ProcessWithContext(context.Background(), state)
+	return state, err
+}
+
+// ProcessWithContext runs the Transaction steps. When a step fails, or the context is canceled before
+// a step, the previous steps are compensated in reverse order. Compensations run even if the context
+// is canceled, with a context carrying the same values. The returned error joins the error of the
+// failed step and the errors of the compensations.
+func (t *Transaction[T]) ProcessWithContext(ctx context.Context, state T) (T, TransactionResult, error) {
+	result := TransactionResult{
+		FailedStepIndex: -1,
+	}
+
 	var i int
 	var err error
 
 	for i < len(t.steps) {
-		state, err = t.steps[i].exec(state)
+		if err = ctx.Err(); err != nil {
+			break
+		}
+
+		state, err = t.exec(ctx, t.steps[i], state)
 		if err != nil {
 			break
 		}
@@ -290,15 +817,90 @@ func (t *Transaction[T]) Process(state T) (T, error) {
 	}
 
 	if err == nil {
-		return state, nil
+		return state, result, nil
 	}
 
+	if i < len(t.steps) {
+		result.FailedStep = t.steps[i].Name
+		result.FailedStepIndex = i
+	}
+
+	errs := []error{err}
+	compensationCtx := withoutCancel(ctx)
+
 	for i > 0 {
 		i--
-		state = t.steps[i].onRollback(state)
+
+		step := t.steps[i]
+		if step.Compensate == nil {
+			continue
+		}
+
+		var compensationErr error
+		state, compensationErr = step.Compensate(compensationCtx, state)
+
+		result.Compensated = append(result.Compensated, step.Name)
+		if compensationErr != nil {
+			result.FailedCompensations = append(result.FailedCompensations, step.Name)
+			errs = append(errs, fmt.Errorf("lo.Transaction: compensation of %q failed: %w", step.Name, compensationErr))
+		}
 	}
 
-	return state, err
+	if len(errs) > 1 {
+		err = xerrors.Join(errs...)
+	}
+
+	return state, result, err
+}
+
+func (t *Transaction[T]) exec(ctx context.Context, step TransactionStep[T], state T) (T, error) {
+	for _, hook := range t.before {
+		hook(ctx, step.Name, state)
+	}
+
+	next := state
+	var err error
+
+	if step.Retry == nil {
+		next, err = step.Exec(ctx, state)
+	} else {
+		_, _, err = AttemptWithPolicyWithContext(ctx, *step.Retry, func(ctx context.Context, _ int, _ time.Duration) error {
+			var attemptErr error
+			next, attemptErr = step.Exec(ctx, state)
+			return attemptErr
+		})
+	}
+
+	for _, hook := range t.after {
+		hook(ctx, step.Name, next, err)
+	}
+
+	return next, err
+}
+
+// withoutCancelCtx is a context that is never canceled and carries the values of its parent.
+type withoutCancelCtx struct {
+	parent context.Context
+}
+
+func withoutCancel(parent context.Context) context.Context {
+	return withoutCancelCtx{parent: parent}
+}
+
+func (withoutCancelCtx) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (withoutCancelCtx) Done() <-chan struct{} {
+	return nil
+}
+
+func (withoutCancelCtx) Err() error {
+	return nil
+}
+
+func (c withoutCancelCtx) Value(key any) any {
+	return c.parent.Value(key)
 }
 
 // @TODO: single mutex per key?
@@ -386,3 +988,470 @@ func NewThrottleByWithCount[T comparable](interval time.Duration, count int, f .
 	}
 	return th.throttledFunc, th.reset
 }
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// refill adds the tokens earned since the last refill, up to the burst size.
+func (b *tokenBucket) refill(now time.Time, rate float64, burst int) {
+	if math.IsInf(rate, 1) {
+		b.tokens = float64(burst)
+	} else if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens += float64(elapsed) * rate
+		if b.tokens > float64(burst) {
+			b.tokens = float64(burst)
+		}
+	}
+
+	b.last = now
+}
+
+// reserve takes a token, possibly going into debt, and returns the time to wait before using it.
+func (b *tokenBucket) reserve(now time.Time, rate float64, burst int) time.Duration {
+	b.refill(now, rate, burst)
+	b.tokens--
+
+	if b.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(math.Ceil(-b.tokens / rate))
+}
+
+// RateLimiterBy is a token-bucket rate limiter with a bucket per key.
+// Unlike NewThrottleByWithCount, callers can wait for a free slot instead of being dropped.
+// A RateLimiterBy is safe for concurrent use.
+type RateLimiterBy[K comparable] struct {
+	mu    sync.Mutex
+	rate  float64 // tokens per nanosecond
+	burst int
+	now   func() time.Time
+	sleep func(ctx context.Context, d time.Duration) error
+
+	buckets   map[K]*tokenBucket
+	sweepSize int
+}
+
+// NewRateLimiterBy creates a token-bucket rate limiter with a bucket per key. Each bucket is refilled
+// with `count` tokens every `interval`, and holds at most `burst` tokens. A bucket starts full.
+// When count is lower than 1, it defaults to 1. When burst is lower than 1, it defaults to count.
+// When interval is lower than or equal to 0, the rate is unlimited.
+func NewRateLimiterBy[K comparable](interval time.Duration, count, burst int) *RateLimiterBy[K] {
+	if count < 1 {
+		count = 1
+	}
+	if burst < 1 {
+		burst = count
+	}
+
+	rate := math.Inf(1)
+	if interval > 0 {
+		rate = float64(count) / float64(interval)
+	}
+
+	return &RateLimiterBy[K]{
+		rate:      rate,
+		burst:     burst,
+		now:       xtime.Now,
+		sleep:     xtime.SleepWithContext,
+		buckets:   map[K]*tokenBucket{},
+		sweepSize: 64,
+	}
+}
+
+// Allow takes a token for the key and returns true when one is available, without waiting.
+func (l *RateLimiterBy[K]) Allow(key K) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b := l.bucket(key)
+	b.refill(l.now(), l.rate, l.burst)
+
+	if b.tokens < 1 {
+		return false
+	}
+
+	b.tokens--
+	return true
+}
+
+// Reserve takes a token for the key and returns how long the caller must wait before acting.
+// Tokens are granted in order, so concurrent reservations wait longer and longer.
+func (l *RateLimiterBy[K]) Reserve(key K) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.bucket(key).reserve(l.now(), l.rate, l.burst)
+}
+
+// Wait blocks until a token is available for the key, or until the context is canceled.
+// Returns ctx.Err() when the context is canceled, in which case the token is given back.
+func (l *RateLimiterBy[K]) Wait(ctx context.Context, key K) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	delay := l.Reserve(key)
+	if delay <= 0 {
+		return nil
+	}
+
+	if err := l.sleep(ctx, delay); err != nil {
+		l.mu.Lock()
+		b := l.bucket(key)
+		b.tokens++
+		if b.tokens > float64(l.burst) {
+			b.tokens = float64(l.burst)
+		}
+		l.mu.Unlock()
+
+		return err
+	}
+
+	return nil
+}
+
+// bucket returns the bucket of a key. Full buckets are dropped from time to time,
+// since they are equivalent to new ones.
+func (l *RateLimiterBy[K]) bucket(key K) *tokenBucket {
+	if b, ok := l.buckets[key]; ok {
+		return b
+	}
+
+	if len(l.buckets) >= l.sweepSize {
+		now := l.now()
+		for k, b := range l.buckets {
+			b.refill(now, l.rate, l.burst)
+			if b.tokens >= float64(l.burst) {
+				delete(l.buckets, k)
+			}
+		}
+
+		l.sweepSize = 2 * len(l.buckets)
+		if l.sweepSize < 64 {
+			l.sweepSize = 64
+		}
+	}
+
+	b := &tokenBucket{tokens: float64(l.burst), last: l.now()}
+	l.buckets[key] = b
+
+	return b
+}
+
+// RateLimiter is a token-bucket rate limiter.
+// Unlike NewThrottleWithCount, callers can wait for a free slot instead of being dropped.
+// A RateLimiter is safe for concurrent use.
+type RateLimiter struct {
+	limiter *RateLimiterBy[struct{}]
+}
+
+// NewRateLimiter creates a token-bucket rate limiter, refilled with `count` tokens every `interval`
+// and holding at most `burst` tokens. The bucket starts full.
+// When count is lower than 1, it defaults to 1. When burst is lower than 1, it defaults to count.
+func NewRateLimiter(interval time.Duration, count, burst int) *RateLimiter {
+	return &RateLimiter{
+		limiter: NewRateLimiterBy[struct{}](interval, count, burst),
+	}
+}
+
+// Allow takes a token and returns true when one is available, without waiting.
+func (l *RateLimiter) Allow() bool {
+	return l.limiter.Allow(struct{}{})
+}
+
+// Reserve takes a token and returns how long the caller must wait before acting.
+// Tokens are granted in order, so concurrent reservations wait longer and longer.
+func (l *RateLimiter) Reserve() time.Duration {
+	return l.limiter.Reserve(struct{}{})
+}
+
+// Wait blocks until a token is available, or until the context is canceled.
+// Returns ctx.Err() when the context is canceled, in which case the token is given back.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	return l.limiter.Wait(ctx, struct{}{})
+}
+
+// CircuitState is the state of a CircuitBreaker.
+type CircuitState int
+
+const (
+	// CircuitClosed lets every call through.
+	CircuitClosed CircuitState = iota
+	// CircuitOpen rejects every call with ErrCircuitOpen until the cool-down is over.
+	CircuitOpen
+	// CircuitHalfOpen lets a limited number of trial calls through to probe the wrapped function.
+	CircuitHalfOpen
+)
+
+// String returns the name of the state.
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// ErrCircuitOpen is returned by CircuitBreaker.Call when the circuit rejects the call.
+var ErrCircuitOpen = errors.New("lo.CircuitBreaker: circuit is open")
+
+// CircuitBreakerOptions configures NewCircuitBreaker.
+type CircuitBreakerOptions struct {
+	// ConsecutiveFailures opens the circuit after this number of failures in a row.
+	// When both ConsecutiveFailures and FailureRatio are unset, it defaults to 5.
+	ConsecutiveFailures int
+
+	// FailureRatio opens the circuit when the ratio of failures among the last WindowSize
+	// calls reaches it. Zero disables the ratio threshold.
+	FailureRatio float64
+
+	// WindowSize is the number of recent calls considered by FailureRatio. The ratio is evaluated
+	// once the window is full. When lower than 1, it defaults to 10.
+	WindowSize int
+
+	// CoolDown is the time spent in the open state before trying half-open calls.
+	// When lower than or equal to 0, it defaults to 1 minute.
+	CoolDown time.Duration
+
+	// HalfOpenCalls is the number of trial calls let through in the half-open state. The circuit
+	// closes after this number of successes, and opens again at the first failure.
+	// When lower than 1, it defaults to 1.
+	HalfOpenCalls int
+
+	// IsFailure, when set, decides which errors count as failures. Other errors are returned
+	// to the caller but count as successes. By default, every non-nil error is a failure.
+	IsFailure func(err error) bool
+
+	// OnStateChange, when set, is called after each state transition.
+	OnStateChange func(from, to CircuitState)
+}
+
+// CircuitBreaker wraps a function and stops calling it after too many failures, giving the
+// underlying service some time to recover. A CircuitBreaker is safe for concurrent use.
+type CircuitBreaker[T any] struct {
+	mu sync.Mutex
+
+	f    func() (T, error)
+	opts CircuitBreakerOptions
+	now  func() time.Time
+
+	state      CircuitState
+	generation uint64
+	openedAt   time.Time
+
+	consecutiveFailures int
+	window              []bool // true for failures, used as a ring buffer
+	windowIndex         int
+	windowFailures      int
+
+	halfOpenInFlight  int
+	halfOpenSuccesses int
+}
+
+// NewCircuitBreaker creates a CircuitBreaker wrapping f, in the closed state.
+func NewCircuitBreaker[T any](f func() (T, error), opts CircuitBreakerOptions) *CircuitBreaker[T] {
+	if opts.ConsecutiveFailures < 1 && opts.FailureRatio <= 0 {
+		opts.ConsecutiveFailures = 5
+	}
+	if opts.WindowSize < 1 {
+		opts.WindowSize = 10
+	}
+	if opts.CoolDown <= 0 {
+		opts.CoolDown = time.Minute
+	}
+	if opts.HalfOpenCalls < 1 {
+		opts.HalfOpenCalls = 1
+	}
+
+	return &CircuitBreaker[T]{
+		f:    f,
+		opts: opts,
+		now:  xtime.Now,
+	}
+}
+
+// Call invokes the wrapped function when the circuit lets the call through,
+// and returns ErrCircuitOpen otherwise. A panic is counted as a failure and propagated.
+func (cb *CircuitBreaker[T]) Call() (result T, err error) {
+	generation, err := cb.before()
+	if err != nil {
+		return result, err
+	}
+
+	failed := true
+	defer func() {
+		cb.after(generation, failed)
+	}()
+
+	result, err = cb.f()
+	failed = err != nil && (cb.opts.IsFailure == nil || cb.opts.IsFailure(err))
+
+	return result, err
+}
+
+// State returns the current state of the circuit.
+func (cb *CircuitBreaker[T]) State() CircuitState {
+	cb.mu.Lock()
+	transitions := cb.refresh()
+	state := cb.state
+	cb.mu.Unlock()
+
+	cb.notify(transitions)
+
+	return state
+}
+
+// Reset closes the circuit and clears the failure counters.
+func (cb *CircuitBreaker[T]) Reset() {
+	cb.mu.Lock()
+	transitions := cb.setState(CircuitClosed)
+	cb.mu.Unlock()
+
+	cb.notify(transitions)
+}
+
+type circuitTransition struct {
+	from CircuitState
+	to   CircuitState
+}
+
+func (cb *CircuitBreaker[T]) before() (uint64, error) {
+	cb.mu.Lock()
+
+	transitions := cb.refresh()
+
+	var err error
+	switch cb.state {
+	case CircuitOpen:
+		err = ErrCircuitOpen
+	case CircuitHalfOpen:
+		if cb.halfOpenInFlight+cb.halfOpenSuccesses >= cb.opts.HalfOpenCalls {
+			err = ErrCircuitOpen
+		} else {
+			cb.halfOpenInFlight++
+		}
+	case CircuitClosed:
+	}
+
+	generation := cb.generation
+	cb.mu.Unlock()
+
+	cb.notify(transitions)
+
+	return generation, err
+}
+
+func (cb *CircuitBreaker[T]) after(generation uint64, failed bool) {
+	cb.mu.Lock()
+
+	var transitions []circuitTransition
+
+	// results of calls started before the last transition are ignored
+	if generation == cb.generation {
+		switch cb.state {
+		case CircuitClosed:
+			transitions = cb.recordClosed(failed)
+		case CircuitHalfOpen:
+			cb.halfOpenInFlight--
+			if failed {
+				transitions = cb.setState(CircuitOpen)
+			} else {
+				cb.halfOpenSuccesses++
+				if cb.halfOpenSuccesses >= cb.opts.HalfOpenCalls {
+					transitions = cb.setState(CircuitClosed)
+				}
+			}
+		case CircuitOpen:
+		}
+	}
+
+	cb.mu.Unlock()
+
+	cb.notify(transitions)
+}
+
+func (cb *CircuitBreaker[T]) recordClosed(failed bool) []circuitTransition {
+	if failed {
+		cb.consecutiveFailures++
+	} else {
+		cb.consecutiveFailures = 0
+	}
+
+	if cb.opts.FailureRatio > 0 {
+		if len(cb.window) < cb.opts.WindowSize {
+			cb.window = append(cb.window, failed)
+		} else {
+			if cb.window[cb.windowIndex] {
+				cb.windowFailures--
+			}
+			cb.window[cb.windowIndex] = failed
+			cb.windowIndex = (cb.windowIndex + 1) % cb.opts.WindowSize
+		}
+
+		if failed {
+			cb.windowFailures++
+		}
+	}
+
+	tripped := cb.opts.ConsecutiveFailures > 0 && cb.consecutiveFailures >= cb.opts.ConsecutiveFailures
+	if cb.opts.FailureRatio > 0 && len(cb.window) == cb.opts.WindowSize {
+		tripped = tripped || float64(cb.windowFailures)/float64(cb.opts.WindowSize) >= cb.opts.FailureRatio
+	}
+
+	if tripped {
+		return cb.setState(CircuitOpen)
+	}
+
+	return nil
+}
+
+// refresh moves the circuit to half-open once the cool-down is over.
+func (cb *CircuitBreaker[T]) refresh() []circuitTransition {
+	if cb.state == CircuitOpen && !cb.now().Before(cb.openedAt.Add(cb.opts.CoolDown)) {
+		return cb.setState(CircuitHalfOpen)
+	}
+
+	return nil
+}
+
+// setState moves the circuit to a new state, with fresh counters.
+func (cb *CircuitBreaker[T]) setState(state CircuitState) []circuitTransition {
+	from := cb.state
+
+	cb.state = state
+	cb.generation++
+	cb.consecutiveFailures = 0
+	cb.window = cb.window[:0]
+	cb.windowIndex = 0
+	cb.windowFailures = 0
+	cb.halfOpenInFlight = 0
+	cb.halfOpenSuccesses = 0
+
+	if state == CircuitOpen {
+		cb.openedAt = cb.now()
+	}
+
+	if from == state {
+		return nil
+	}
+
+	return []circuitTransition{{from: from, to: state}}
+}
+
+func (cb *CircuitBreaker[T]) notify(transitions []circuitTransition) {
+	if cb.opts.OnStateChange == nil {
+		return
+	}
+
+	for _, t := range transitions {
+		cb.opts.OnStateChange(t.from, t.to)
+	}
+}
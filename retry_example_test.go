@@ -3,6 +3,7 @@
 package lo
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"sync"
@@ -130,6 +131,123 @@ func ExampleAttemptWithDelay() {
 	// 2 1ms error
 }
 
+func ExampleAttemptWithPolicy() {
+	errUnavailable := errors.New("service unavailable")
+
+	policy := RetryPolicy{
+		MaxAttempts: 5,
+		Delay:       time.Millisecond,
+		MaxDelay:    3 * time.Millisecond,
+		OnRetry: func(index int, err error, delay time.Duration) {
+			fmt.Printf("attempt %d failed: %v, retrying in %v\n", index, err, delay)
+		},
+	}
+
+	count, _, err := AttemptWithPolicy(policy, func(i int, _ time.Duration) error {
+		if i < 3 {
+			return errUnavailable
+		}
+
+		return nil
+	})
+
+	fmt.Printf("%v %v\n", count, err)
+	// Output:
+	// attempt 0 failed: service unavailable, retrying in 1ms
+	// attempt 1 failed: service unavailable, retrying in 2ms
+	// attempt 2 failed: service unavailable, retrying in 3ms
+	// 4 <nil>
+}
+
+func ExamplePermanent() {
+	errNotFound := errors.New("not found")
+
+	count, _, err := AttemptWithPolicy(RetryPolicy{MaxAttempts: 5}, func(i int, _ time.Duration) error {
+		return Permanent(errNotFound)
+	})
+
+	fmt.Printf("%v %v", count, err)
+	// Output: 1 not found
+}
+
+func ExampleAttemptWithContext() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	count, _, err := AttemptWithContext(ctx, 5, func(ctx context.Context, i int) error {
+		if i == 1 {
+			// eg: the HTTP request that started the retry loop was abandoned
+			cancel()
+		}
+
+		return errors.New("error")
+	})
+
+	fmt.Printf("%v %v", count, err)
+	// Output: 2 context canceled
+}
+
+func ExampleAttemptWithDelayWithContext() {
+	count, duration, err := AttemptWithDelayWithContext(context.Background(), 5, time.Millisecond, func(ctx context.Context, i int, _ time.Duration) error {
+		if i < 2 {
+			return errors.New("error")
+		}
+
+		return nil
+	})
+
+	fmt.Printf("%v %v %v", count, duration.Truncate(time.Millisecond), err)
+	// Output: 3 2ms <nil>
+}
+
+func ExampleNewCircuitBreaker() {
+	cb := NewCircuitBreaker(func() (string, error) {
+		return "", errors.New("service unavailable")
+	}, CircuitBreakerOptions{
+		ConsecutiveFailures: 2,
+		CoolDown:            time.Minute,
+		OnStateChange: func(from, to CircuitState) {
+			fmt.Printf("%v -> %v\n", from, to)
+		},
+	})
+
+	for i := 0; i < 3; i++ {
+		_, err := cb.Call()
+		fmt.Println(err)
+	}
+	// Output:
+	// service unavailable
+	// closed -> open
+	// service unavailable
+	// lo.CircuitBreaker: circuit is open
+}
+
+func ExampleNewRateLimiter() {
+	limiter := NewRateLimiter(time.Second, 2, 3)
+
+	for i := 0; i < 5; i++ {
+		fmt.Println(limiter.Allow())
+	}
+	// Output:
+	// true
+	// true
+	// true
+	// false
+	// false
+}
+
+func ExampleNewRateLimiterBy() {
+	limiter := NewRateLimiterBy[string](time.Second, 1, 1)
+
+	fmt.Println(limiter.Allow("foo"))
+	fmt.Println(limiter.Allow("foo"))
+	fmt.Println(limiter.Allow("bar"))
+	// Output:
+	// true
+	// false
+	// true
+}
+
 func ExampleTransaction() {
 	transaction := NewTransaction[int]().
 		Then(
@@ -250,6 +368,75 @@ func ExampleTransaction_error() {
 	// error
 }
 
+func ExampleTransaction_ProcessWithContext() {
+	transaction := NewTransaction[int]().
+		BeforeStep(func(_ context.Context, step string, state int) {
+			fmt.Println("running", step)
+		}).
+		Step(TransactionStep[int]{
+			Name: "create account",
+			Exec: func(_ context.Context, state int) (int, error) {
+				return state + 1, nil
+			},
+			Compensate: func(_ context.Context, state int) (int, error) {
+				return state - 1, errors.New("account is locked")
+			},
+		}).
+		Step(TransactionStep[int]{
+			Name: "create database",
+			Exec: func(_ context.Context, state int) (int, error) {
+				return state, errors.New("quota exceeded")
+			},
+		})
+
+	_, result, err := transaction.ProcessWithContext(context.Background(), 0)
+
+	fmt.Println(result.FailedStep)
+	fmt.Println(result.Compensated)
+	fmt.Println(err)
+	// Output:
+	// running create account
+	// running create database
+	// create database
+	// [create account]
+	// quota exceeded
+	// lo.Transaction: compensation of "create account" failed: account is locked
+}
+
+func ExampleNewDebounceWithOptions() {
+	debounce, cancel, flush := NewDebounceWithOptions(time.Hour, DebounceOptions{Leading: true, Trailing: true}, func(value string, count int) {
+		fmt.Println(value, count)
+	})
+
+	debounce("a")
+	debounce("b")
+	debounce("c")
+
+	flush()
+	cancel()
+	// Output:
+	// a 1
+	// c 2
+}
+
+func ExampleNewDebounceByWithOptions() {
+	debounce, cancel, flush := NewDebounceByWithOptions(time.Hour, DebounceOptions{}, func(key, value string, count int) {
+		fmt.Println(key, value, count)
+	})
+
+	debounce("samuel", "foo")
+	debounce("samuel", "bar")
+	debounce("john", "baz")
+
+	flush("samuel")
+	flush("john")
+	cancel("samuel")
+	cancel("john")
+	// Output:
+	// samuel bar 2
+	// john baz 1
+}
+
 func ExampleNewThrottle() {
 	throttle, reset := NewThrottle(100*time.Millisecond, func() {
 		fmt.Println("Called once in every 100ms")
@@ -1,14 +1,233 @@
 package lo
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/samber/lo/internal/xtime"
 )
 
+func TestDebounceWithOptions(t *testing.T) { //nolint:paralleltest
+	// t.Parallel()
+
+	type call struct {
+		value int
+		count int
+	}
+
+	newRecorder := func() (func(value, count int), func() []call) {
+		mu := sync.Mutex{}
+		calls := []call{}
+
+		return func(value, count int) {
+				mu.Lock()
+				calls = append(calls, call{value, count})
+				mu.Unlock()
+			}, func() []call {
+				mu.Lock()
+				defer mu.Unlock()
+				return append([]call{}, calls...)
+			}
+	}
+
+	t.Run("trailing edge receives the latest value", func(t *testing.T) { //nolint:paralleltest
+		// t.Parallel()
+		is := assert.New(t)
+
+		record, calls := newRecorder()
+		debounce, _, _ := NewDebounceWithOptions(50*time.Millisecond, DebounceOptions{}, record)
+
+		for i := 1; i <= 5; i++ {
+			debounce(i)
+		}
+
+		is.Empty(calls())
+		time.Sleep(100 * time.Millisecond)
+		is.Equal([]call{{5, 5}}, calls())
+	})
+
+	t.Run("leading edge", func(t *testing.T) { //nolint:paralleltest
+		// t.Parallel()
+		is := assert.New(t)
+
+		record, calls := newRecorder()
+		debounce, _, _ := NewDebounceWithOptions(50*time.Millisecond, DebounceOptions{Leading: true}, record)
+
+		for i := 1; i <= 5; i++ {
+			debounce(i)
+		}
+
+		is.Equal([]call{{1, 1}}, calls())
+		time.Sleep(100 * time.Millisecond)
+		is.Equal([]call{{1, 1}}, calls())
+
+		// a new burst starts after the wait
+		debounce(6)
+		is.Equal([]call{{1, 1}, {6, 1}}, calls())
+	})
+
+	t.Run("leading and trailing edges", func(t *testing.T) { //nolint:paralleltest
+		// t.Parallel()
+		is := assert.New(t)
+
+		record, calls := newRecorder()
+		debounce, _, _ := NewDebounceWithOptions(50*time.Millisecond, DebounceOptions{Leading: true, Trailing: true}, record)
+
+		debounce(1)
+		time.Sleep(100 * time.Millisecond)
+		is.Equal([]call{{1, 1}}, calls())
+
+		for i := 2; i <= 5; i++ {
+			debounce(i)
+		}
+		time.Sleep(100 * time.Millisecond)
+		is.Equal([]call{{1, 1}, {2, 1}, {5, 3}}, calls())
+	})
+
+	t.Run("max wait", func(t *testing.T) { //nolint:paralleltest
+		// t.Parallel()
+		is := assert.New(t)
+
+		record, calls := newRecorder()
+		debounce, _, _ := NewDebounceWithOptions(50*time.Millisecond, DebounceOptions{MaxWait: 100 * time.Millisecond}, record)
+
+		// the calls never pause for 50ms, so only MaxWait triggers the callbacks
+		for i := 1; i <= 20; i++ {
+			debounce(i)
+			time.Sleep(15 * time.Millisecond)
+		}
+
+		is.GreaterOrEqual(len(calls()), 2)
+
+		time.Sleep(100 * time.Millisecond)
+
+		total := 0
+		for _, c := range calls() {
+			total += c.count
+		}
+		is.Equal(20, total)
+		is.Equal(20, calls()[len(calls())-1].value)
+	})
+
+	t.Run("flush", func(t *testing.T) { //nolint:paralleltest
+		// t.Parallel()
+		is := assert.New(t)
+
+		record, calls := newRecorder()
+		debounce, _, flush := NewDebounceWithOptions(50*time.Millisecond, DebounceOptions{}, record)
+
+		flush()
+		is.Empty(calls())
+
+		debounce(1)
+		debounce(2)
+		debounce(3)
+		flush()
+		is.Equal([]call{{3, 3}}, calls())
+
+		time.Sleep(100 * time.Millisecond)
+		is.Equal([]call{{3, 3}}, calls())
+	})
+
+	t.Run("flush skips the trailing edge when disabled", func(t *testing.T) { //nolint:paralleltest
+		// t.Parallel()
+		is := assert.New(t)
+
+		record, calls := newRecorder()
+		debounce, _, flush := NewDebounceWithOptions(50*time.Millisecond, DebounceOptions{Leading: true}, record)
+
+		debounce(1)
+		debounce(2)
+		flush()
+		is.Equal([]call{{1, 1}}, calls())
+
+		// flush ended the burst
+		debounce(3)
+		is.Equal([]call{{1, 1}, {3, 1}}, calls())
+	})
+
+	t.Run("cancel", func(t *testing.T) { //nolint:paralleltest
+		// t.Parallel()
+		is := assert.New(t)
+
+		record, calls := newRecorder()
+		debounce, cancel, flush := NewDebounceWithOptions(50*time.Millisecond, DebounceOptions{MaxWait: 60 * time.Millisecond}, record)
+
+		debounce(1)
+		cancel()
+		debounce(2)
+		flush()
+
+		time.Sleep(100 * time.Millisecond)
+		is.Empty(calls())
+	})
+}
+
+func TestDebounceByWithOptions(t *testing.T) { //nolint:paralleltest
+	// t.Parallel()
+	is := assert.New(t)
+
+	mu := sync.Mutex{}
+	calls := map[string][]string{}
+
+	debounce, cancel, flush := NewDebounceByWithOptions(50*time.Millisecond, DebounceOptions{Leading: true, Trailing: true}, func(key, value string, count int) {
+		mu.Lock()
+		calls[key] = append(calls[key], fmt.Sprintf("%s:%d", value, count))
+		mu.Unlock()
+	})
+
+	debounce("a", "a1")
+	debounce("a", "a2")
+	debounce("a", "a3")
+	debounce("b", "b1")
+	debounce("b", "b2")
+	debounce("c", "c1")
+	debounce("c", "c2")
+
+	flush("b")
+	flush("unknown")
+	cancel("c")
+
+	mu.Lock()
+	is.Equal(map[string][]string{
+		"a": {"a1:1"},
+		"b": {"b1:1", "b2:1"},
+		"c": {"c1:1"},
+	}, calls)
+	mu.Unlock()
+
+	time.Sleep(100 * time.Millisecond)
+
+	mu.Lock()
+	is.Equal(map[string][]string{
+		"a": {"a1:1", "a3:2"},
+		"b": {"b1:1", "b2:1"},
+		"c": {"c1:1"},
+	}, calls)
+	mu.Unlock()
+
+	// a canceled key starts over
+	debounce("c", "c3")
+
+	mu.Lock()
+	is.Equal([]string{"c1:1", "c3:1"}, calls["c"])
+	mu.Unlock()
+
+	cancel("a")
+	cancel("b")
+	cancel("c")
+}
+
 func TestAttempt(t *testing.T) {
 	t.Parallel()
 
@@ -707,6 +926,180 @@ func TestTransaction(t *testing.T) {
 	})
 }
 
+func TestTransactionWithContext(t *testing.T) {
+	t.Parallel()
+
+	add := func(n int) func(context.Context, int) (int, error) {
+		return func(_ context.Context, state int) (int, error) {
+			return state + n, nil
+		}
+	}
+	fail := func(err error) func(context.Context, int) (int, error) {
+		return func(_ context.Context, state int) (int, error) {
+			return state, err
+		}
+	}
+
+	t.Run("no error", func(t *testing.T) {
+		t.Parallel()
+		is := assert.New(t)
+
+		state, result, err := NewTransaction[int]().
+			Step(TransactionStep[int]{Name: "first", Exec: add(1), Compensate: add(-1)}).
+			Step(TransactionStep[int]{Name: "second", Exec: add(2), Compensate: add(-2)}).
+			ProcessWithContext(context.Background(), 10)
+
+		is.NoError(err)
+		is.Equal(13, state)
+		is.Equal(TransactionResult{FailedStepIndex: -1}, result)
+	})
+
+	t.Run("with error", func(t *testing.T) {
+		t.Parallel()
+		is := assert.New(t)
+
+		state, result, err := NewTransaction[int]().
+			Step(TransactionStep[int]{Name: "first", Exec: add(1), Compensate: add(-1)}).
+			Step(TransactionStep[int]{Exec: add(2)}).
+			Then(
+				func(state int) (int, error) {
+					return state + 4, nil
+				},
+				func(state int) int {
+					return state - 4
+				},
+			).
+			Step(TransactionStep[int]{Name: "fourth", Exec: fail(assert.AnError), Compensate: add(-8)}).
+			Step(TransactionStep[int]{Name: "fifth", Exec: add(16), Compensate: add(-16)}).
+			ProcessWithContext(context.Background(), 10)
+
+		is.Equal(assert.AnError, err)
+		is.Equal(12, state)
+		is.Equal(TransactionResult{
+			FailedStep:      "fourth",
+			FailedStepIndex: 3,
+			Compensated:     []string{"step 3", "first"},
+		}, result)
+	})
+
+	t.Run("with compensation errors", func(t *testing.T) {
+		t.Parallel()
+		is := assert.New(t)
+
+		errCompensation := errors.New("compensation error")
+
+		state, result, err := NewTransaction[int]().
+			Step(TransactionStep[int]{Name: "first", Exec: add(1), Compensate: add(-1)}).
+			Step(TransactionStep[int]{Name: "second", Exec: add(2), Compensate: fail(errCompensation)}).
+			Step(TransactionStep[int]{Name: "third", Exec: fail(assert.AnError)}).
+			ProcessWithContext(context.Background(), 10)
+
+		is.ErrorIs(err, assert.AnError)
+		is.ErrorIs(err, errCompensation)
+		is.Contains(err.Error(), `lo.Transaction: compensation of "second" failed: compensation error`)
+		is.Equal(12, state)
+		is.Equal(TransactionResult{
+			FailedStep:          "third",
+			FailedStepIndex:     2,
+			Compensated:         []string{"second", "first"},
+			FailedCompensations: []string{"second"},
+		}, result)
+	})
+
+	t.Run("with retry policy", func(t *testing.T) {
+		t.Parallel()
+		is := assert.New(t)
+
+		var attempts []int
+		flaky := func(_ context.Context, state int) (int, error) {
+			attempts = append(attempts, state)
+			if len(attempts) < 3 {
+				return state + 100, assert.AnError
+			}
+			return state + 1, nil
+		}
+
+		state, _, err := NewTransaction[int]().
+			Step(TransactionStep[int]{Name: "flaky", Exec: flaky, Retry: &RetryPolicy{MaxAttempts: 3, Backoff: BackoffConstant}}).
+			ProcessWithContext(context.Background(), 10)
+
+		is.NoError(err)
+		is.Equal(11, state)
+		is.Equal([]int{10, 10, 10}, attempts)
+
+		attempts = nil
+		state, result, err := NewTransaction[int]().
+			Step(TransactionStep[int]{Name: "first", Exec: add(1), Compensate: add(-1)}).
+			Step(TransactionStep[int]{Name: "flaky", Exec: flaky, Retry: &RetryPolicy{MaxAttempts: 2, Backoff: BackoffConstant}}).
+			ProcessWithContext(context.Background(), 10)
+
+		is.ErrorIs(err, assert.AnError)
+		is.Equal(110, state)
+		is.Equal([]int{11, 11}, attempts)
+		is.Equal("flaky", result.FailedStep)
+	})
+
+	t.Run("with hooks", func(t *testing.T) {
+		t.Parallel()
+		is := assert.New(t)
+
+		logs := []string{}
+
+		_, _, err := NewTransaction[int]().
+			BeforeStep(func(_ context.Context, step string, state int) {
+				logs = append(logs, fmt.Sprintf("before %s: %d", step, state))
+			}).
+			AfterStep(func(_ context.Context, step string, state int, err error) {
+				logs = append(logs, fmt.Sprintf("after %s: %d %v", step, state, err))
+			}).
+			Step(TransactionStep[int]{Name: "first", Exec: add(1)}).
+			Step(TransactionStep[int]{Name: "second", Exec: fail(assert.AnError)}).
+			ProcessWithContext(context.Background(), 10)
+
+		is.ErrorIs(err, assert.AnError)
+		is.Equal([]string{
+			"before first: 10",
+			"after first: 11 <nil>",
+			"before second: 11",
+			"after second: 11 " + assert.AnError.Error(),
+		}, logs)
+	})
+
+	t.Run("with canceled context", func(t *testing.T) {
+		t.Parallel()
+		is := assert.New(t)
+
+		type ctxKey struct{}
+
+		ctx, cancel := context.WithCancel(context.WithValue(context.Background(), ctxKey{}, "value"))
+
+		state, result, err := NewTransaction[int]().
+			Step(TransactionStep[int]{
+				Name: "first",
+				Exec: func(_ context.Context, state int) (int, error) {
+					cancel()
+					return state + 1, nil
+				},
+				Compensate: func(ctx context.Context, state int) (int, error) {
+					// compensations are not canceled
+					is.NoError(ctx.Err())
+					is.Equal("value", ctx.Value(ctxKey{}))
+					return state - 1, nil
+				},
+			}).
+			Step(TransactionStep[int]{Name: "second", Exec: add(2)}).
+			ProcessWithContext(ctx, 10)
+
+		is.ErrorIs(err, context.Canceled)
+		is.Equal(10, state)
+		is.Equal(TransactionResult{
+			FailedStep:      "second",
+			FailedStepIndex: 1,
+			Compensated:     []string{"first"},
+		}, result)
+	})
+}
+
 func TestNewThrottle(t *testing.T) { //nolint:paralleltest
 	// t.Parallel()
 	callCount := 0
@@ -918,3 +1311,775 @@ func TestNewThrottleByWithCount(t *testing.T) { //nolint:paralleltest
 		is.Equal(6, callCountB)
 	})
 }
+
+func TestRetryPolicyNextDelay(t *testing.T) {
+	t.Parallel()
+
+	t.Run("exponential", func(t *testing.T) {
+		t.Parallel()
+		is := assert.New(t)
+
+		policy := RetryPolicy{Delay: 10 * time.Millisecond, MaxDelay: 50 * time.Millisecond}
+
+		var delays []time.Duration
+		var delay time.Duration
+		for i := 0; i < 5; i++ {
+			delay = policy.nextDelay(i, delay)
+			delays = append(delays, delay)
+		}
+
+		is.Equal([]time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 40 * time.Millisecond, 50 * time.Millisecond, 50 * time.Millisecond}, delays)
+
+		is.Equal(90*time.Millisecond, RetryPolicy{Delay: 10 * time.Millisecond, Multiplier: 3}.nextDelay(2, 0))
+		is.Equal(time.Duration(math.MaxInt64), RetryPolicy{Delay: time.Second}.nextDelay(1000, 0))
+	})
+
+	t.Run("constant", func(t *testing.T) {
+		t.Parallel()
+		is := assert.New(t)
+
+		policy := RetryPolicy{Delay: 10 * time.Millisecond, Backoff: BackoffConstant}
+		is.Equal(10*time.Millisecond, policy.nextDelay(0, 0))
+		is.Equal(10*time.Millisecond, policy.nextDelay(10, 10*time.Millisecond))
+	})
+
+	t.Run("decorrelated", func(t *testing.T) {
+		t.Parallel()
+		is := assert.New(t)
+
+		policy := RetryPolicy{Delay: 10 * time.Millisecond, MaxDelay: time.Second, Backoff: BackoffDecorrelated}
+
+		delay := policy.nextDelay(0, 0)
+		is.Equal(10*time.Millisecond, delay)
+
+		for i := 1; i < 20; i++ {
+			next := policy.nextDelay(i, delay)
+			is.GreaterOrEqual(next, 10*time.Millisecond)
+			is.LessOrEqual(next, time.Second)
+			is.LessOrEqual(next, 3*delay)
+			delay = next
+		}
+	})
+
+	t.Run("jitter", func(t *testing.T) {
+		t.Parallel()
+		is := assert.New(t)
+
+		full := RetryPolicy{Delay: 100 * time.Millisecond, Backoff: BackoffConstant, Jitter: JitterFull}
+		equal := RetryPolicy{Delay: 100 * time.Millisecond, Backoff: BackoffConstant, Jitter: JitterEqual}
+
+		for i := 0; i < 20; i++ {
+			delay := full.nextDelay(i, 0)
+			is.GreaterOrEqual(delay, time.Duration(0))
+			is.LessOrEqual(delay, 100*time.Millisecond)
+
+			delay = equal.nextDelay(i, 0)
+			is.GreaterOrEqual(delay, 50*time.Millisecond)
+			is.LessOrEqual(delay, 100*time.Millisecond)
+		}
+
+		is.Zero(RetryPolicy{Jitter: JitterFull}.nextDelay(0, 0))
+	})
+}
+
+func TestRetryPolicyIsRetryable(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	errTimeout := errors.New("timeout")
+	errNotFound := errors.New("not found")
+
+	is.True(RetryPolicy{}.IsRetryable(errTimeout))
+	is.False(RetryPolicy{}.IsRetryable(Permanent(errTimeout)))
+	is.False(RetryPolicy{}.IsRetryable(fmt.Errorf("wrapped: %w", Permanent(errTimeout))))
+
+	permanent := RetryPolicy{PermanentErrors: []error{errNotFound}}
+	is.True(permanent.IsRetryable(errTimeout))
+	is.False(permanent.IsRetryable(errNotFound))
+	is.False(permanent.IsRetryable(fmt.Errorf("wrapped: %w", errNotFound)))
+
+	retryable := RetryPolicy{RetryableErrors: []error{errTimeout}}
+	is.True(retryable.IsRetryable(fmt.Errorf("wrapped: %w", errTimeout)))
+	is.False(retryable.IsRetryable(errNotFound))
+
+	var pathErr *os.PathError
+	byType := RetryPolicy{RetryIf: func(err error) bool {
+		return !errors.As(err, &pathErr)
+	}}
+	is.True(byType.IsRetryable(errTimeout))
+	is.False(byType.IsRetryable(&os.PathError{Op: "open", Path: "/", Err: errNotFound}))
+
+	is.NoError(Permanent(nil))
+	is.ErrorIs(Permanent(errTimeout), errTimeout)
+	is.EqualError(Permanent(errTimeout), "timeout")
+}
+
+func TestAttemptWithPolicy(t *testing.T) { //nolint:paralleltest
+	// t.Parallel()
+
+	err := errors.New("failed")
+
+	t.Run("succeeds after some attempts", func(t *testing.T) { //nolint:paralleltest
+		is := assert.New(t)
+
+		policy := RetryPolicy{MaxAttempts: 10, Delay: 10 * time.Millisecond}
+		var durations []time.Duration
+
+		iter, duration, e := AttemptWithPolicy(policy, func(i int, d time.Duration) error {
+			durations = append(durations, d)
+			if i == 3 {
+				return nil
+			}
+			return err
+		})
+
+		is.Equal(4, iter)
+		is.Equal(70*time.Millisecond, duration)
+		is.Equal([]time.Duration{0, 10 * time.Millisecond, 30 * time.Millisecond, 70 * time.Millisecond}, durations)
+		is.NoError(e)
+	})
+
+	t.Run("stops after max attempts", func(t *testing.T) { //nolint:paralleltest
+		is := assert.New(t)
+
+		policy := RetryPolicy{MaxAttempts: 3, Delay: 10 * time.Millisecond, Backoff: BackoffConstant}
+
+		iter, duration, e := AttemptWithPolicy(policy, func(i int, d time.Duration) error {
+			return err
+		})
+
+		is.Equal(3, iter)
+		is.Equal(20*time.Millisecond, duration)
+		is.ErrorIs(e, err)
+	})
+
+	t.Run("stops before the deadline", func(t *testing.T) { //nolint:paralleltest
+		is := assert.New(t)
+
+		policy := RetryPolicy{Delay: 10 * time.Millisecond, Deadline: 100 * time.Millisecond}
+
+		iter, duration, e := AttemptWithPolicy(policy, func(i int, d time.Duration) error {
+			return err
+		})
+
+		// 10 + 20 + 40 = 70ms, the next pause of 80ms would exceed the deadline
+		is.Equal(4, iter)
+		is.Equal(70*time.Millisecond, duration)
+		is.ErrorIs(e, err)
+	})
+
+	t.Run("stops on permanent errors", func(t *testing.T) { //nolint:paralleltest
+		is := assert.New(t)
+
+		errFatal := errors.New("fatal")
+		policy := RetryPolicy{MaxAttempts: 10, Delay: 10 * time.Millisecond, PermanentErrors: []error{errFatal}}
+
+		iter, _, e := AttemptWithPolicy(policy, func(i int, d time.Duration) error {
+			if i == 2 {
+				return errFatal
+			}
+			return err
+		})
+		is.Equal(3, iter)
+		is.Equal(errFatal, e)
+
+		iter, duration, e := AttemptWithPolicy(policy, func(i int, d time.Duration) error {
+			return Permanent(err)
+		})
+		is.Equal(1, iter)
+		is.Zero(duration)
+		is.Equal(err, e)
+	})
+
+	t.Run("calls the retry hook", func(t *testing.T) { //nolint:paralleltest
+		is := assert.New(t)
+
+		type retry struct {
+			index int
+			err   error
+			delay time.Duration
+		}
+
+		var retries []retry
+		policy := RetryPolicy{
+			MaxAttempts: 3,
+			Delay:       10 * time.Millisecond,
+			OnRetry: func(index int, err error, delay time.Duration) {
+				retries = append(retries, retry{index, err, delay})
+			},
+		}
+
+		_, _, e := AttemptWithPolicy(policy, func(i int, d time.Duration) error {
+			return err
+		})
+
+		is.ErrorIs(e, err)
+		is.Equal([]retry{{0, err, 10 * time.Millisecond}, {1, err, 20 * time.Millisecond}}, retries)
+	})
+}
+
+func TestAttemptWithContext(t *testing.T) { //nolint:paralleltest
+	// t.Parallel()
+	is := assert.New(t)
+
+	err := errors.New("failed")
+
+	type ctxKey struct{}
+	ctx := context.WithValue(context.Background(), ctxKey{}, "value")
+
+	iter, _, e := AttemptWithContext(ctx, 5, func(ctx context.Context, i int) error {
+		is.Equal("value", ctx.Value(ctxKey{}))
+		if i == 2 {
+			return nil
+		}
+		return err
+	})
+	is.Equal(3, iter)
+	is.NoError(e)
+
+	iter, _, e = AttemptWithContext(ctx, 5, func(ctx context.Context, i int) error {
+		return err
+	})
+	is.Equal(5, iter)
+	is.ErrorIs(e, err)
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	iter, duration, e := AttemptWithContext(canceled, 5, func(ctx context.Context, i int) error {
+		return nil
+	})
+	is.Zero(iter)
+	is.Zero(duration)
+	is.ErrorIs(e, context.Canceled)
+
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+
+	iter, _, e = AttemptWithContext(ctx, 0, func(ctx context.Context, i int) error {
+		if i == 3 {
+			cancel()
+		}
+		return err
+	})
+	is.Equal(4, iter)
+	is.ErrorIs(e, context.Canceled)
+}
+
+func TestAttemptWithDelayWithContext(t *testing.T) { //nolint:paralleltest
+	// t.Parallel()
+	is := assert.New(t)
+
+	err := errors.New("failed")
+
+	iter, duration, e := AttemptWithDelayWithContext(context.Background(), 5, 10*time.Millisecond, func(ctx context.Context, i int, d time.Duration) error {
+		if i == 2 {
+			return nil
+		}
+		return err
+	})
+	is.Equal(3, iter)
+	is.Equal(20*time.Millisecond, duration)
+	is.NoError(e)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	iter, duration, e = AttemptWithDelayWithContext(ctx, 5, 10*time.Millisecond, func(ctx context.Context, i int, d time.Duration) error {
+		if i == 1 {
+			cancel()
+		}
+		return err
+	})
+	is.Equal(2, iter)
+	is.Equal(10*time.Millisecond, duration)
+	is.ErrorIs(e, context.Canceled)
+}
+
+func TestAttemptWhileWithContext(t *testing.T) { //nolint:paralleltest
+	// t.Parallel()
+	is := assert.New(t)
+
+	err := errors.New("failed")
+
+	iter, _, e := AttemptWhileWithContext(context.Background(), 5, func(ctx context.Context, i int) (error, bool) {
+		return err, i < 1
+	})
+	is.Equal(2, iter)
+	is.ErrorIs(e, err)
+
+	iter, duration, e := AttemptWhileWithDelayWithContext(context.Background(), 5, 10*time.Millisecond, func(ctx context.Context, i int, d time.Duration) (error, bool) {
+		if i == 3 {
+			return nil, true
+		}
+		return err, true
+	})
+	is.Equal(4, iter)
+	is.Equal(30*time.Millisecond, duration)
+	is.NoError(e)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+	cancel()
+
+	iter, _, e = AttemptWhileWithDelayWithContext(ctx, 5, 10*time.Millisecond, func(ctx context.Context, i int, d time.Duration) (error, bool) {
+		return nil, true
+	})
+	is.Zero(iter)
+	is.ErrorIs(e, context.Canceled)
+}
+
+func TestAttemptWithPolicyWithContext(t *testing.T) { //nolint:paralleltest
+	// t.Parallel()
+	is := assert.New(t)
+
+	err := errors.New("failed")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var retries int
+	policy := RetryPolicy{
+		Delay: 10 * time.Millisecond,
+		OnRetry: func(index int, err error, delay time.Duration) {
+			retries++
+		},
+	}
+
+	iter, duration, e := AttemptWithPolicyWithContext(ctx, policy, func(ctx context.Context, i int, d time.Duration) error {
+		if i == 2 {
+			cancel()
+		}
+		return err
+	})
+	is.Equal(3, iter)
+	is.Equal(30*time.Millisecond, duration)
+	is.ErrorIs(e, context.Canceled)
+	is.Equal(3, retries)
+}
+
+func TestAttemptWithContextInterruptsDelay(t *testing.T) { //nolint:paralleltest
+	// t.Parallel()
+	testWithTimeout(t, time.Second)
+	is := assert.New(t)
+
+	clock := xtime.NewRealClock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	e := clock.SleepWithContext(ctx, time.Hour)
+	is.ErrorIs(e, context.DeadlineExceeded)
+	is.Less(time.Since(start), 500*time.Millisecond)
+
+	is.NoError(clock.SleepWithContext(context.Background(), time.Millisecond))
+}
+
+func TestAttemptWithDelayWithContextCanceledDuringDelay(t *testing.T) { //nolint:paralleltest
+	// t.Parallel()
+	testWithTimeout(t, time.Second)
+	is := assert.New(t)
+
+	// the delay must block to be interrupted
+	xtime.SetClock(xtime.NewRealClock())
+	defer xtime.SetClock(xtime.NewFakeClock())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := errors.New("failed")
+
+	start := time.Now()
+	iter, _, e := AttemptWithDelayWithContext(ctx, 3, time.Hour, func(_ context.Context, _ int, _ time.Duration) error {
+		time.AfterFunc(20*time.Millisecond, cancel)
+		return err
+	})
+
+	is.ErrorIs(e, context.Canceled)
+	is.Equal(1, iter)
+	is.Less(time.Since(start), 500*time.Millisecond)
+}
+
+func TestCircuitBreakerConsecutiveFailures(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	clock := xtime.NewFakeClock()
+	err := errors.New("failed")
+
+	var fail bool
+	calls := 0
+	var transitions []string
+
+	cb := NewCircuitBreaker(func() (int, error) {
+		calls++
+		if fail {
+			return 0, err
+		}
+		return 42, nil
+	}, CircuitBreakerOptions{
+		ConsecutiveFailures: 3,
+		CoolDown:            time.Minute,
+		OnStateChange: func(from, to CircuitState) {
+			transitions = append(transitions, from.String()+"->"+to.String())
+		},
+	})
+	cb.now = clock.Now
+
+	value, e := cb.Call()
+	is.NoError(e)
+	is.Equal(42, value)
+
+	fail = true
+	for i := 0; i < 2; i++ {
+		_, e = cb.Call()
+		is.ErrorIs(e, err)
+	}
+	is.Equal(CircuitClosed, cb.State())
+
+	// a success resets the consecutive failures
+	fail = false
+	_, _ = cb.Call()
+	fail = true
+	for i := 0; i < 3; i++ {
+		_, _ = cb.Call()
+	}
+	is.Equal(CircuitOpen, cb.State())
+	is.Equal(7, calls)
+
+	_, e = cb.Call()
+	is.ErrorIs(e, ErrCircuitOpen)
+	is.Equal(7, calls)
+
+	clock.Sleep(59 * time.Second)
+	is.Equal(CircuitOpen, cb.State())
+
+	// the trial call fails: the circuit opens again for a full cool-down
+	clock.Sleep(time.Second)
+	is.Equal(CircuitHalfOpen, cb.State())
+	_, e = cb.Call()
+	is.ErrorIs(e, err)
+	is.Equal(CircuitOpen, cb.State())
+
+	clock.Sleep(time.Minute)
+	fail = false
+	value, e = cb.Call()
+	is.NoError(e)
+	is.Equal(42, value)
+	is.Equal(CircuitClosed, cb.State())
+
+	is.Equal([]string{"closed->open", "open->half-open", "half-open->open", "open->half-open", "half-open->closed"}, transitions)
+}
+
+func TestCircuitBreakerFailureRatio(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	err := errors.New("failed")
+	results := []bool{true, false, true, false, true, false, false, false, true, true}
+
+	i := 0
+	cb := NewCircuitBreaker(func() (struct{}, error) {
+		failed := results[i%len(results)]
+		i++
+		if failed {
+			return struct{}{}, err
+		}
+		return struct{}{}, nil
+	}, CircuitBreakerOptions{
+		FailureRatio: 0.5,
+		WindowSize:   4,
+	})
+
+	// the ratio is only evaluated once the window is full
+	for j := 0; j < 3; j++ {
+		_, _ = cb.Call()
+	}
+	is.Equal(CircuitClosed, cb.State())
+
+	// true, false, true, false: 50% of failures
+	_, _ = cb.Call()
+	is.Equal(CircuitOpen, cb.State())
+
+	cb.Reset()
+	is.Equal(CircuitClosed, cb.State())
+
+	// true, false, false, false: 25% of failures
+	for j := 0; j < 4; j++ {
+		_, _ = cb.Call()
+	}
+	is.Equal(CircuitClosed, cb.State())
+
+	// false, false, true, true: 50% of failures in the sliding window
+	_, _ = cb.Call()
+	is.Equal(CircuitClosed, cb.State())
+	_, _ = cb.Call()
+	is.Equal(CircuitOpen, cb.State())
+}
+
+func TestCircuitBreakerHalfOpen(t *testing.T) { //nolint:paralleltest
+	// t.Parallel()
+	is := assert.New(t)
+
+	clock := xtime.NewFakeClock()
+	release := make(chan struct{})
+	started := make(chan struct{}, 10)
+	err := errors.New("failed")
+
+	fail := true
+	cb := NewCircuitBreaker(func() (int, error) {
+		if fail {
+			return 0, err
+		}
+		started <- struct{}{}
+		<-release
+		return 1, nil
+	}, CircuitBreakerOptions{
+		ConsecutiveFailures: 1,
+		HalfOpenCalls:       2,
+		CoolDown:            time.Second,
+	})
+	cb.now = clock.Now
+
+	_, _ = cb.Call()
+	is.Equal(CircuitOpen, cb.State())
+
+	clock.Sleep(time.Second)
+	fail = false
+
+	var wg sync.WaitGroup
+	for j := 0; j < 2; j++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, e := cb.Call()
+			is.NoError(e)
+		}()
+	}
+	<-started
+	<-started
+
+	// only 2 trial calls are let through
+	_, e := cb.Call()
+	is.ErrorIs(e, ErrCircuitOpen)
+	is.Equal(CircuitHalfOpen, cb.State())
+
+	close(release)
+	wg.Wait()
+	is.Equal(CircuitClosed, cb.State())
+}
+
+func TestCircuitBreakerIsFailure(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	errNotFound := errors.New("not found")
+
+	cb := NewCircuitBreaker(func() (int, error) {
+		return 0, errNotFound
+	}, CircuitBreakerOptions{
+		ConsecutiveFailures: 1,
+		IsFailure: func(err error) bool {
+			return !errors.Is(err, errNotFound)
+		},
+	})
+
+	for i := 0; i < 5; i++ {
+		_, e := cb.Call()
+		is.ErrorIs(e, errNotFound)
+	}
+	is.Equal(CircuitClosed, cb.State())
+}
+
+func TestCircuitBreakerPanic(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	cb := NewCircuitBreaker(func() (int, error) {
+		panic("boom")
+	}, CircuitBreakerOptions{ConsecutiveFailures: 1})
+
+	is.PanicsWithValue("boom", func() {
+		_, _ = cb.Call()
+	})
+	is.Equal(CircuitOpen, cb.State())
+}
+
+func TestCircuitStateString(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	is.Equal("closed", CircuitClosed.String())
+	is.Equal("open", CircuitOpen.String())
+	is.Equal("half-open", CircuitHalfOpen.String())
+	is.Equal("unknown", CircuitState(42).String())
+}
+
+func TestRateLimiter(t *testing.T) { //nolint:paralleltest
+	// t.Parallel()
+
+	newLimiter := func(interval time.Duration, count, burst int) (*RateLimiter, *xtime.FakeClock) {
+		clock := xtime.NewFakeClock()
+		l := NewRateLimiter(interval, count, burst)
+		l.limiter.now = clock.Now
+		l.limiter.sleep = clock.SleepWithContext
+		return l, clock
+	}
+
+	t.Run("allow", func(t *testing.T) { //nolint:paralleltest
+		// t.Parallel()
+		is := assert.New(t)
+
+		l, clock := newLimiter(time.Second, 2, 4)
+
+		// the bucket starts full
+		for i := 0; i < 4; i++ {
+			is.True(l.Allow())
+		}
+		is.False(l.Allow())
+
+		clock.Sleep(499 * time.Millisecond)
+		is.False(l.Allow())
+		clock.Sleep(time.Millisecond)
+		is.True(l.Allow())
+		is.False(l.Allow())
+
+		// the bucket never holds more than the burst
+		clock.Sleep(time.Hour)
+		for i := 0; i < 4; i++ {
+			is.True(l.Allow())
+		}
+		is.False(l.Allow())
+	})
+
+	t.Run("reserve", func(t *testing.T) { //nolint:paralleltest
+		// t.Parallel()
+		is := assert.New(t)
+
+		l, clock := newLimiter(100*time.Millisecond, 1, 1)
+
+		is.Zero(l.Reserve())
+		is.Equal(100*time.Millisecond, l.Reserve())
+		is.Equal(200*time.Millisecond, l.Reserve())
+
+		clock.Sleep(250 * time.Millisecond)
+		is.Equal(50*time.Millisecond, l.Reserve())
+		is.False(l.Allow())
+	})
+
+	t.Run("wait", func(t *testing.T) { //nolint:paralleltest
+		// t.Parallel()
+		is := assert.New(t)
+
+		l, clock := newLimiter(100*time.Millisecond, 1, 2)
+		start := clock.Now()
+
+		for i := 0; i < 5; i++ {
+			is.NoError(l.Wait(context.Background()))
+		}
+
+		// 2 tokens from the burst, then 1 every 100ms
+		is.Equal(300*time.Millisecond, clock.Since(start))
+	})
+
+	t.Run("wait with canceled context", func(t *testing.T) { //nolint:paralleltest
+		// t.Parallel()
+		is := assert.New(t)
+
+		l, _ := newLimiter(time.Second, 1, 1)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		is.ErrorIs(l.Wait(ctx), context.Canceled)
+		is.True(l.Allow())
+	})
+
+	t.Run("unlimited", func(t *testing.T) { //nolint:paralleltest
+		// t.Parallel()
+		is := assert.New(t)
+
+		l := NewRateLimiter(0, 1, 1)
+		for i := 0; i < 100; i++ {
+			is.True(l.Allow())
+			is.Zero(l.Reserve())
+		}
+	})
+}
+
+func TestRateLimiterWaitInterrupted(t *testing.T) { //nolint:paralleltest
+	// t.Parallel()
+	testWithTimeout(t, time.Second)
+	is := assert.New(t)
+
+	l := NewRateLimiter(time.Hour, 1, 1)
+	l.limiter.now = time.Now
+	l.limiter.sleep = xtime.NewRealClock().SleepWithContext
+
+	is.True(l.Allow())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	is.ErrorIs(l.Wait(ctx), context.DeadlineExceeded)
+
+	// the token reserved by the canceled call was given back
+	is.InDelta(1*time.Hour, l.Reserve(), float64(time.Second))
+}
+
+func TestRateLimiterBy(t *testing.T) { //nolint:paralleltest
+	// t.Parallel()
+	is := assert.New(t)
+
+	clock := xtime.NewFakeClock()
+	l := NewRateLimiterBy[string](time.Second, 1, 2)
+	l.now = clock.Now
+	l.sleep = clock.SleepWithContext
+
+	is.True(l.Allow("tenant-a"))
+	is.True(l.Allow("tenant-a"))
+	is.False(l.Allow("tenant-a"))
+
+	// each key has its own bucket
+	is.True(l.Allow("tenant-b"))
+	is.Zero(l.Reserve("tenant-b"))
+	is.Equal(time.Second, l.Reserve("tenant-b"))
+
+	is.NoError(l.Wait(context.Background(), "tenant-a"))
+	is.True(clock.Since(clock.Now()) == 0)
+
+	// full buckets are dropped when the map grows
+	for i := 0; i < 1000; i++ {
+		is.True(l.Allow(strconv.Itoa(i)))
+		clock.Sleep(time.Second)
+	}
+	l.mu.Lock()
+	is.Less(len(l.buckets), 200)
+	l.mu.Unlock()
+}
+
+func TestRateLimiterConcurrency(t *testing.T) { //nolint:paralleltest
+	// t.Parallel()
+	is := assert.New(t)
+
+	clock := xtime.NewFakeClock()
+	l := NewRateLimiter(time.Second, 10, 10)
+	l.limiter.now = clock.Now
+
+	var allowed int32
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				if l.Allow() {
+					atomic.AddInt32(&allowed, 1)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	is.Equal(int32(10), allowed)
+}
@@ -0,0 +1,227 @@
+package lo
+
+// Set is a collection of unique comparable values. It is backed by a map, so it can be used
+// with the map helpers (eg: lo.Keys) and converted from the result of lo.Keyify without copy.
+// The zero value is a nil set: it can be read, but must be initialized with NewSet before adding items.
+type Set[T comparable] map[T]struct{}
+
+// NewSet creates a set containing the given items.
+func NewSet[T comparable](items ...T) Set[T] {
+	s := make(Set[T], len(items))
+	s.Add(items...)
+	return s
+}
+
+// Add inserts items into the set.
+func (s Set[T]) Add(items ...T) {
+	for i := range items {
+		s[items[i]] = struct{}{}
+	}
+}
+
+// Remove deletes items from the set.
+func (s Set[T]) Remove(items ...T) {
+	for i := range items {
+		delete(s, items[i])
+	}
+}
+
+// Has returns true if the item belongs to the set.
+func (s Set[T]) Has(item T) bool {
+	_, ok := s[item]
+	return ok
+}
+
+// HasAll returns true if every item belongs to the set.
+func (s Set[T]) HasAll(items ...T) bool {
+	for i := range items {
+		if !s.Has(items[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// HasAny returns true if at least one item belongs to the set.
+func (s Set[T]) HasAny(items ...T) bool {
+	for i := range items {
+		if s.Has(items[i]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Len returns the number of items in the set.
+func (s Set[T]) Len() int {
+	return len(s)
+}
+
+// IsEmpty returns true if the set has no item.
+func (s Set[T]) IsEmpty() bool {
+	return len(s) == 0
+}
+
+// Clear removes every item from the set.
+func (s Set[T]) Clear() {
+	for k := range s {
+		delete(s, k)
+	}
+}
+
+// Clone returns a shallow copy of the set.
+func (s Set[T]) Clone() Set[T] {
+	result := make(Set[T], len(s))
+
+	for k := range s {
+		result[k] = struct{}{}
+	}
+
+	return result
+}
+
+// Slice returns the items of the set. The order is not specified.
+func (s Set[T]) Slice() []T {
+	result := make([]T, 0, len(s))
+
+	for k := range s {
+		result = append(result, k)
+	}
+
+	return result
+}
+
+// ForEach invokes callback for each item of the set. The order is not specified.
+func (s Set[T]) ForEach(callback func(item T)) {
+	for k := range s {
+		callback(k)
+	}
+}
+
+// Union returns a new set with the items of the set and of every other set.
+func (s Set[T]) Union(others ...Set[T]) Set[T] {
+	size := len(s)
+	for i := range others {
+		size += len(others[i])
+	}
+
+	result := make(Set[T], size)
+
+	for k := range s {
+		result[k] = struct{}{}
+	}
+
+	for i := range others {
+		for k := range others[i] {
+			result[k] = struct{}{}
+		}
+	}
+
+	return result
+}
+
+// Intersect returns a new set with the items belonging to the set and to every other set.
+func (s Set[T]) Intersect(others ...Set[T]) Set[T] {
+	result := Set[T]{}
+
+	for k := range s {
+		found := true
+
+		for i := range others {
+			if !others[i].Has(k) {
+				found = false
+				break
+			}
+		}
+
+		if found {
+			result[k] = struct{}{}
+		}
+	}
+
+	return result
+}
+
+// Difference returns a new set with the items of the set that belong to none of the other sets.
+func (s Set[T]) Difference(others ...Set[T]) Set[T] {
+	result := Set[T]{}
+
+	for k := range s {
+		found := false
+
+		for i := range others {
+			if others[i].Has(k) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			result[k] = struct{}{}
+		}
+	}
+
+	return result
+}
+
+// SymmetricDifference returns a new set with the items belonging to exactly one of the 2 sets.
+func (s Set[T]) SymmetricDifference(other Set[T]) Set[T] {
+	result := Set[T]{}
+
+	for k := range s {
+		if !other.Has(k) {
+			result[k] = struct{}{}
+		}
+	}
+
+	for k := range other {
+		if !s.Has(k) {
+			result[k] = struct{}{}
+		}
+	}
+
+	return result
+}
+
+// IsSubsetOf returns true if every item of the set belongs to the other set.
+func (s Set[T]) IsSubsetOf(other Set[T]) bool {
+	if len(s) > len(other) {
+		return false
+	}
+
+	for k := range s {
+		if !other.Has(k) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsSupersetOf returns true if every item of the other set belongs to the set.
+func (s Set[T]) IsSupersetOf(other Set[T]) bool {
+	return other.IsSubsetOf(s)
+}
+
+// IsDisjoint returns true if the 2 sets have no item in common.
+func (s Set[T]) IsDisjoint(other Set[T]) bool {
+	small, large := s, other
+	if len(small) > len(large) {
+		small, large = large, small
+	}
+
+	for k := range small {
+		if large.Has(k) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Equal returns true if both sets contain the same items.
+func (s Set[T]) Equal(other Set[T]) bool {
+	return len(s) == len(other) && s.IsSubsetOf(other)
+}
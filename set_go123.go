@@ -0,0 +1,17 @@
+//go:build go1.23
+
+package lo
+
+import "iter"
+
+// All returns a sequence of the items of the set. The order is not specified.
+// Items added or removed during the iteration may or may not be yielded.
+func (s Set[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for k := range s {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
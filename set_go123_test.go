@@ -0,0 +1,30 @@
+//go:build go1.23
+
+package lo
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetAll(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	s := NewSet(1, 2, 3)
+
+	result := slices.Collect(s.All())
+	slices.Sort(result)
+	is.Equal([]int{1, 2, 3}, result)
+
+	count := 0
+	for range s.All() {
+		count++
+		break
+	}
+	is.Equal(1, count)
+
+	is.Empty(slices.Collect(NewSet[int]().All()))
+}
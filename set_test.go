@@ -0,0 +1,149 @@
+package lo
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func sortedSet(s Set[int]) []int {
+	result := s.Slice()
+	sort.Ints(result)
+	return result
+}
+
+func TestNewSet(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	s := NewSet(1, 2, 2, 3)
+	is.Equal(3, s.Len())
+	is.Equal([]int{1, 2, 3}, sortedSet(s))
+
+	empty := NewSet[int]()
+	is.NotNil(empty)
+	is.True(empty.IsEmpty())
+
+	// interoperability with map helpers
+	is.Equal(Set[int]{1: {}, 2: {}}, Set[int](Keyify([]int{1, 2, 1})))
+	is.ElementsMatch([]int{1, 2, 3}, Keys(s))
+}
+
+func TestSetAddRemoveHas(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	s := NewSet[string]()
+	s.Add("a", "b")
+	s.Add("b", "c")
+	is.Equal(3, s.Len())
+	is.True(s.Has("a"))
+	is.False(s.Has("z"))
+	is.True(s.HasAll("a", "c"))
+	is.False(s.HasAll("a", "z"))
+	is.True(s.HasAny("z", "c"))
+	is.False(s.HasAny("y", "z"))
+	is.True(s.HasAll())
+	is.False(s.HasAny())
+
+	s.Remove("a", "z")
+	is.False(s.Has("a"))
+	is.Equal(2, s.Len())
+
+	clone := s.Clone()
+	s.Clear()
+	is.True(s.IsEmpty())
+	is.Equal(2, clone.Len())
+
+	var nilSet Set[string]
+	is.False(nilSet.Has("a"))
+	is.Zero(nilSet.Len())
+	is.Empty(nilSet.Slice())
+}
+
+func TestSetForEach(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	sum := 0
+	NewSet(1, 2, 3).ForEach(func(item int) {
+		sum += item
+	})
+	is.Equal(6, sum)
+}
+
+func TestSetUnion(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	a := NewSet(1, 2, 3)
+	b := NewSet(3, 4)
+	c := NewSet(5)
+
+	is.Equal([]int{1, 2, 3, 4, 5}, sortedSet(a.Union(b, c)))
+	is.Equal([]int{1, 2, 3}, sortedSet(a.Union()))
+	is.Equal([]int{1, 2, 3}, sortedSet(a), "input not mutated")
+	is.ElementsMatch(Union([]int{1, 2, 3}, []int{3, 4}, []int{5}), a.Union(b, c).Slice())
+}
+
+func TestSetIntersect(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	a := NewSet(1, 2, 3, 4)
+	b := NewSet(2, 3, 4, 5)
+	c := NewSet(3, 4, 6)
+
+	is.Equal([]int{3, 4}, sortedSet(a.Intersect(b, c)))
+	is.Equal([]int{1, 2, 3, 4}, sortedSet(a.Intersect()))
+	is.Empty(a.Intersect(NewSet[int]()))
+	is.ElementsMatch(Intersect([]int{1, 2, 3, 4}, []int{2, 3, 4, 5}), a.Intersect(b).Slice())
+}
+
+func TestSetDifference(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	a := NewSet(1, 2, 3, 4)
+	b := NewSet(2, 5)
+	c := NewSet(4)
+
+	is.Equal([]int{1, 3}, sortedSet(a.Difference(b, c)))
+	is.Equal([]int{1, 2, 3, 4}, sortedSet(a.Difference()))
+
+	left, _ := Difference([]int{1, 2, 3, 4}, []int{2, 5})
+	is.ElementsMatch(left, a.Difference(b).Slice())
+}
+
+func TestSetSymmetricDifference(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	a := NewSet(1, 2, 3)
+	b := NewSet(3, 4)
+
+	is.Equal([]int{1, 2, 4}, sortedSet(a.SymmetricDifference(b)))
+	is.Empty(a.SymmetricDifference(a))
+}
+
+func TestSetSubsetSuperset(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	a := NewSet(1, 2, 3)
+	b := NewSet(1, 2)
+	c := NewSet(4)
+
+	is.True(b.IsSubsetOf(a))
+	is.False(a.IsSubsetOf(b))
+	is.True(a.IsSubsetOf(a))
+	is.True(NewSet[int]().IsSubsetOf(a))
+	is.True(a.IsSupersetOf(b))
+	is.False(b.IsSupersetOf(a))
+	is.True(a.IsDisjoint(c))
+	is.False(a.IsDisjoint(b))
+	is.True(a.Equal(NewSet(3, 2, 1)))
+	is.False(a.Equal(b))
+	is.False(a.Equal(NewSet(1, 2, 4)))
+}
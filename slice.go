@@ -1276,6 +1276,84 @@ func IsSortedByKey[T any, K constraints.Ordered](collection []T, iteratee func(i
 	return IsSortedBy(collection, iteratee)
 }
 
+// InsertSorted returns a copy of a collection sorted in ascending order, with the items inserted
+// at their sorted position. Inserted items are placed after the equal values already present.
+// The collection is not modified.
+func InsertSorted[T constraints.Ordered, Slice ~[]T](collection Slice, items ...T) Slice {
+	return InsertSortedBy(collection, func(item T) T { return item }, items...)
+}
+
+// InsertSortedBy returns a copy of a collection sorted in ascending order by iteratee, with the items
+// inserted at their sorted position. Inserted items are placed after the items having the same key,
+// and keep their relative order. The collection is not modified.
+func InsertSortedBy[T any, K constraints.Ordered, Slice ~[]T](collection Slice, iteratee func(item T) K, items ...T) Slice {
+	sorted := append(make(Slice, 0, len(items)), items...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return iteratee(sorted[i]) < iteratee(sorted[j])
+	})
+
+	return MergeSortedBy(iteratee, collection, sorted)
+}
+
+// MergeSorted merges collections sorted in ascending order into a single sorted collection.
+// It uses a k-way heap: the complexity is O(n log k) for k collections and n items in total.
+// Equal values are taken from the collections in the order they are given.
+func MergeSorted[T constraints.Ordered, Slice ~[]T](lists ...Slice) Slice {
+	return MergeSortedBy(func(item T) T { return item }, lists...)
+}
+
+// MergeSortedBy merges collections sorted in ascending order by iteratee into a single sorted collection.
+// It uses a k-way heap: the complexity is O(n log k) for k collections and n items in total.
+// Items having the same key are taken from the collections in the order they are given.
+func MergeSortedBy[T any, K constraints.Ordered, Slice ~[]T](iteratee func(item T) K, lists ...Slice) Slice {
+	var size int
+	for i := range lists {
+		size += len(lists[i])
+	}
+
+	result := make(Slice, 0, size)
+
+	forEachSortedBy(iteratee, lists, func(item T, _ K) {
+		result = append(result, item)
+	})
+
+	return result
+}
+
+type sortedCursor[K constraints.Ordered] struct {
+	list  int
+	index int
+	key   K
+}
+
+// forEachSortedBy invokes callback for each item of the sorted lists, in merged order.
+func forEachSortedBy[T any, K constraints.Ordered, Slice ~[]T](iteratee func(item T) K, lists []Slice, callback func(item T, key K)) {
+	cursors := make([]sortedCursor[K], 0, len(lists))
+	for i := range lists {
+		if len(lists[i]) > 0 {
+			cursors = append(cursors, sortedCursor[K]{list: i, key: iteratee(lists[i][0])})
+		}
+	}
+
+	pq := NewPriorityQueue(func(a, b sortedCursor[K]) bool {
+		return a.key < b.key || (!(b.key < a.key) && a.list < b.list)
+	}, cursors...)
+
+	cursor, ok := pq.Pop()
+	for ok {
+		list := lists[cursor.list]
+		callback(list[cursor.index], cursor.key)
+
+		cursor.index++
+		if cursor.index < len(list) {
+			cursor.key = iteratee(list[cursor.index])
+			cursor = pq.PushPop(cursor)
+		} else {
+			cursor, ok = pq.Pop()
+		}
+	}
+}
+
 // Splice inserts multiple elements at index i. A negative index counts back
 // from the end of the slice. The helper is protected against overflow errors.
 // Play: https://go.dev/play/p/G5_GhkeSUBA
@@ -4057,3 +4057,88 @@ func TestTrimSuffix(t *testing.T) {
 		})
 	}
 }
+
+func TestInsertSorted(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	collection := []int{1, 3, 5}
+
+	is.Equal([]int{0, 1, 3, 4, 5, 9}, InsertSorted(collection, 9, 4, 0))
+	is.Equal([]int{1, 3, 5}, collection)
+	is.Equal([]int{1, 3, 5}, InsertSorted(collection))
+	is.Equal([]int{2}, InsertSorted([]int{}, 2))
+
+	type myStrings []string
+	allStrings := myStrings{"a", "c"}
+	nonempty := InsertSorted(allStrings, "b")
+	is.IsType(nonempty, allStrings, "type preserved")
+}
+
+func TestInsertSortedBy(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	type event struct {
+		name string
+		ts   int
+	}
+
+	ts := func(e event) int {
+		return e.ts
+	}
+
+	result := InsertSortedBy([]event{{"a", 10}, {"b", 20}}, ts, event{"d", 20}, event{"c", 5}, event{"e", 20})
+	is.Equal([]event{{"c", 5}, {"a", 10}, {"b", 20}, {"d", 20}, {"e", 20}}, result)
+}
+
+func TestMergeSorted(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	is.Equal([]int{1, 2, 3, 4, 5, 6, 7}, MergeSorted([]int{1, 4, 7}, []int{2, 5}, []int{3, 6}))
+	is.Equal([]int{1, 1, 2, 2}, MergeSorted([]int{1, 2}, []int{}, []int{1, 2}))
+	is.Equal([]int{1, 2}, MergeSorted([]int{1, 2}))
+	is.Empty(MergeSorted[int, []int]())
+	is.Empty(MergeSorted([]int{}, []int{}))
+
+	lists := make([][]int, 10)
+	var expected []int
+	for i := 0; i < 1000; i++ {
+		value := (i * 7919) % 1009
+		lists[i%10] = append(lists[i%10], value)
+		expected = append(expected, value)
+	}
+	for i := range lists {
+		sort.Ints(lists[i])
+	}
+	sort.Ints(expected)
+	is.Equal(expected, MergeSorted(lists...))
+
+	type myStrings []string
+	allStrings := myStrings{"a", "c"}
+	nonempty := MergeSorted(allStrings, myStrings{"b"})
+	is.IsType(nonempty, allStrings, "type preserved")
+}
+
+func TestMergeSortedBy(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	type event struct {
+		source string
+		ts     int
+	}
+
+	ts := func(e event) int {
+		return e.ts
+	}
+
+	result := MergeSortedBy(
+		ts,
+		[]event{{"a", 1}, {"a", 3}},
+		[]event{{"b", 1}, {"b", 2}},
+		[]event{{"c", 3}},
+	)
+	is.Equal([]event{{"a", 1}, {"b", 1}, {"b", 2}, {"a", 3}, {"c", 3}}, result)
+}
@@ -0,0 +1,284 @@
+package lo
+
+import (
+	"sort"
+	"time"
+
+	"github.com/samber/lo/internal/xtime"
+)
+
+// TimeWindow is the aggregate of the messages of a time window, from Start (inclusive) to End (exclusive).
+type TimeWindow[R any] struct {
+	Start time.Time
+	End   time.Time
+	Count int
+	Value R
+}
+
+// TimeWindowOptions configures a TimeWindows aggregator.
+type TimeWindowOptions[T any] struct {
+	// Timestamp returns the event time of a message. Defaults to the time the message is added
+	// (processing time).
+	Timestamp func(item T) time.Time
+	// AllowedLateness is how long a window stays open after its end, in event time, waiting for
+	// late messages. Defaults to 0.
+	AllowedLateness time.Duration
+}
+
+// TimeWindows groups messages into time windows and reduces the messages of each window.
+// The watermark is the latest event time seen: a window is emitted once the watermark reaches its
+// end plus the allowed lateness. Messages arriving after all their windows were emitted are dropped.
+// A TimeWindows is not safe for concurrent use.
+type TimeWindows[T, R any] struct {
+	size     time.Duration
+	hop      time.Duration
+	gap      time.Duration
+	lateness time.Duration
+
+	reducer   func(agg R, item T) R
+	initial   R
+	timestamp func(item T) time.Time
+	now       func() time.Time
+
+	open      []*timeWindowBucket[T, R] // sorted by start
+	watermark time.Time
+	started   bool
+	dropped   int
+}
+
+type timeWindowBucket[T, R any] struct {
+	start time.Time
+	end   time.Time
+	count int
+	value R
+	items []Tuple2[time.Time, T] // session windows only: sessions are reduced once emitted, since they can merge
+}
+
+// NewTumblingWindows creates an aggregator of fixed-size, non-overlapping time windows, aligned on the
+// zero time like time.Truncate.
+func NewTumblingWindows[T, R any](size time.Duration, reducer func(agg R, item T) R, initial R, options TimeWindowOptions[T]) *TimeWindows[T, R] {
+	if size <= 0 {
+		panic("lo.NewTumblingWindows: size must be greater than 0")
+	}
+
+	return newTimeWindows(size, size, 0, reducer, initial, options)
+}
+
+// NewHoppingWindows creates an aggregator of fixed-size time windows starting every hop, aligned on the
+// zero time like time.Truncate. A message belongs to every window covering its event time: windows overlap
+// when hop is lower than size, and messages between windows are ignored when hop is greater than size.
+func NewHoppingWindows[T, R any](size, hop time.Duration, reducer func(agg R, item T) R, initial R, options TimeWindowOptions[T]) *TimeWindows[T, R] {
+	if size <= 0 || hop <= 0 {
+		panic("lo.NewHoppingWindows: size and hop must be greater than 0")
+	}
+
+	return newTimeWindows(size, hop, 0, reducer, initial, options)
+}
+
+// NewSessionWindows creates an aggregator of session windows: a session gathers the messages separated by
+// less than gap, and ends gap after its last message. Sessions keep their messages until they are emitted.
+func NewSessionWindows[T, R any](gap time.Duration, reducer func(agg R, item T) R, initial R, options TimeWindowOptions[T]) *TimeWindows[T, R] {
+	if gap <= 0 {
+		panic("lo.NewSessionWindows: gap must be greater than 0")
+	}
+
+	return newTimeWindows(0, 0, gap, reducer, initial, options)
+}
+
+func newTimeWindows[T, R any](size, hop, gap time.Duration, reducer func(agg R, item T) R, initial R, options TimeWindowOptions[T]) *TimeWindows[T, R] {
+	lateness := options.AllowedLateness
+	if lateness < 0 {
+		lateness = 0
+	}
+
+	return &TimeWindows[T, R]{
+		size:      size,
+		hop:       hop,
+		gap:       gap,
+		lateness:  lateness,
+		reducer:   reducer,
+		initial:   initial,
+		timestamp: options.Timestamp,
+		now:       xtime.Now,
+	}
+}
+
+// Add assigns a message to its windows, and returns the windows closed by the progress of the watermark,
+// ordered by start.
+func (w *TimeWindows[T, R]) Add(item T) []TimeWindow[R] {
+	var ts time.Time
+	if w.timestamp != nil {
+		ts = w.timestamp(item)
+	} else {
+		ts = w.now()
+	}
+
+	if !w.started || ts.After(w.watermark) {
+		w.watermark = ts
+		w.started = true
+	}
+
+	if w.gap > 0 {
+		w.addToSession(item, ts)
+	} else {
+		w.addToWindows(item, ts)
+	}
+
+	return w.emit(false)
+}
+
+// Flush returns every open window, ordered by start, without waiting for the watermark.
+func (w *TimeWindows[T, R]) Flush() []TimeWindow[R] {
+	return w.emit(true)
+}
+
+// Dropped returns the number of messages that arrived after all their windows were emitted.
+func (w *TimeWindows[T, R]) Dropped() int {
+	return w.dropped
+}
+
+// isClosed returns true once the watermark reached the end of the window plus the allowed lateness.
+func (w *TimeWindows[T, R]) isClosed(end time.Time) bool {
+	return !end.Add(w.lateness).After(w.watermark)
+}
+
+func (w *TimeWindows[T, R]) addToWindows(item T, ts time.Time) {
+	added, late := false, false
+
+	// from the latest window containing the message to the earliest one
+	for start := ts.Truncate(w.hop); start.Add(w.size).After(ts); start = start.Add(-w.hop) {
+		end := start.Add(w.size)
+		if w.isClosed(end) {
+			// the earlier windows are closed too
+			late = true
+			break
+		}
+
+		bucket := w.bucket(start, end)
+		bucket.value = w.reducer(bucket.value, item)
+		bucket.count++
+		added = true
+	}
+
+	if !added && late {
+		w.dropped++
+	}
+}
+
+// bucket returns the open window starting at start, or inserts it.
+func (w *TimeWindows[T, R]) bucket(start, end time.Time) *timeWindowBucket[T, R] {
+	i := 0
+	for i < len(w.open) && w.open[i].start.Before(start) {
+		i++
+	}
+
+	if i < len(w.open) && w.open[i].start.Equal(start) {
+		return w.open[i]
+	}
+
+	bucket := &timeWindowBucket[T, R]{
+		start: start,
+		end:   end,
+		value: w.initial,
+	}
+
+	w.open = append(w.open, nil)
+	copy(w.open[i+1:], w.open[i:])
+	w.open[i] = bucket
+
+	return bucket
+}
+
+func (w *TimeWindows[T, R]) addToSession(item T, ts time.Time) {
+	session := &timeWindowBucket[T, R]{
+		start: ts,
+		end:   ts.Add(w.gap),
+		items: []Tuple2[time.Time, T]{T2(ts, item)},
+	}
+
+	if w.isClosed(session.end) {
+		w.dropped++
+		return
+	}
+
+	// sessions are disjoint: the ones overlapping the new message are contiguous
+	open := make([]*timeWindowBucket[T, R], 0, len(w.open)+1)
+	inserted := false
+
+	for _, s := range w.open {
+		switch {
+		case !s.end.After(session.start):
+			open = append(open, s)
+
+		case !session.end.After(s.start):
+			if !inserted {
+				open = append(open, session)
+				inserted = true
+			}
+
+			open = append(open, s)
+
+		default:
+			if s.start.Before(session.start) {
+				session.start = s.start
+			}
+
+			if s.end.After(session.end) {
+				session.end = s.end
+			}
+
+			session.items = append(s.items, session.items...)
+		}
+	}
+
+	if !inserted {
+		open = append(open, session)
+	}
+
+	w.open = open
+}
+
+// emit removes and returns the closed windows, or every window when all is true. Windows have the same
+// size, or are disjoint sessions: the closed ones are always the first ones.
+func (w *TimeWindows[T, R]) emit(all bool) []TimeWindow[R] {
+	n := 0
+	for n < len(w.open) && (all || w.isClosed(w.open[n].end)) {
+		n++
+	}
+
+	if n == 0 {
+		return nil
+	}
+
+	windows := make([]TimeWindow[R], 0, n)
+
+	for i := 0; i < n; i++ {
+		bucket := w.open[i]
+		w.open[i] = nil // release the reference for the garbage collector
+
+		if bucket.items != nil {
+			// messages are reduced in event time order
+			sort.SliceStable(bucket.items, func(a, b int) bool {
+				return bucket.items[a].A.Before(bucket.items[b].A)
+			})
+
+			bucket.value = w.initial
+			for j := range bucket.items {
+				bucket.value = w.reducer(bucket.value, bucket.items[j].B)
+			}
+
+			bucket.count = len(bucket.items)
+		}
+
+		windows = append(windows, TimeWindow[R]{
+			Start: bucket.start,
+			End:   bucket.end,
+			Count: bucket.count,
+			Value: bucket.value,
+		})
+	}
+
+	w.open = w.open[n:]
+
+	return windows
+}
@@ -0,0 +1,205 @@
+package lo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/samber/lo/internal/xtime"
+	"github.com/stretchr/testify/assert"
+)
+
+type timedEvent struct {
+	at    time.Time
+	value int
+}
+
+func newTimedEvents(origin time.Time, offsetsAndValues ...int) []timedEvent {
+	events := make([]timedEvent, 0, len(offsetsAndValues)/2)
+	for i := 0; i+1 < len(offsetsAndValues); i += 2 {
+		events = append(events, timedEvent{at: origin.Add(time.Duration(offsetsAndValues[i]) * time.Second), value: offsetsAndValues[i+1]})
+	}
+
+	return events
+}
+
+func sumTimedEvents(agg int, e timedEvent) int {
+	return agg + e.value
+}
+
+func timedEventTimestamp(e timedEvent) time.Time {
+	return e.at
+}
+
+func addTimedEvents[T, R any](w *TimeWindows[T, R], events []T) []TimeWindow[R] {
+	var windows []TimeWindow[R]
+	for i := range events {
+		windows = append(windows, w.Add(events[i])...)
+	}
+
+	return windows
+}
+
+func TestTumblingWindows(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	origin := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(seconds int) time.Time {
+		return origin.Add(time.Duration(seconds) * time.Second)
+	}
+
+	w := NewTumblingWindows(10*time.Second, sumTimedEvents, 0, TimeWindowOptions[timedEvent]{
+		Timestamp: timedEventTimestamp,
+	})
+
+	// seconds, value
+	closed := addTimedEvents(w, newTimedEvents(origin, 1, 1, 5, 2, 12, 3, 9, 4, 25, 5))
+	is.Equal([]TimeWindow[int]{
+		{Start: at(0), End: at(10), Count: 2, Value: 3},
+		{Start: at(10), End: at(20), Count: 1, Value: 3},
+	}, closed)
+
+	// the event of second 9 arrived after its window was emitted
+	is.Equal(1, w.Dropped())
+
+	is.Equal([]TimeWindow[int]{
+		{Start: at(20), End: at(30), Count: 1, Value: 5},
+	}, w.Flush())
+	is.Empty(w.Flush())
+
+	is.PanicsWithValue("lo.NewTumblingWindows: size must be greater than 0", func() {
+		NewTumblingWindows(0, sumTimedEvents, 0, TimeWindowOptions[timedEvent]{})
+	})
+}
+
+func TestTumblingWindowsAllowedLateness(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	origin := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(seconds int) time.Time {
+		return origin.Add(time.Duration(seconds) * time.Second)
+	}
+
+	w := NewTumblingWindows(10*time.Second, sumTimedEvents, 0, TimeWindowOptions[timedEvent]{
+		Timestamp:       timedEventTimestamp,
+		AllowedLateness: 5 * time.Second,
+	})
+
+	is.Empty(addTimedEvents(w, newTimedEvents(origin, 1, 1, 12, 2, 9, 3)))
+	is.Zero(w.Dropped())
+
+	is.Equal([]TimeWindow[int]{
+		{Start: at(0), End: at(10), Count: 2, Value: 4},
+	}, w.Add(timedEvent{at: at(15), value: 4}))
+
+	is.Empty(w.Add(timedEvent{at: at(8), value: 5}))
+	is.Equal(1, w.Dropped())
+
+	is.Equal([]TimeWindow[int]{
+		{Start: at(10), End: at(20), Count: 2, Value: 6},
+	}, w.Flush())
+}
+
+func TestHoppingWindows(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	origin := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(seconds int) time.Time {
+		return origin.Add(time.Duration(seconds) * time.Second)
+	}
+
+	w := NewHoppingWindows(10*time.Second, 5*time.Second, sumTimedEvents, 0, TimeWindowOptions[timedEvent]{
+		Timestamp: timedEventTimestamp,
+	})
+
+	closed := addTimedEvents(w, newTimedEvents(origin, 1, 1, 7, 2, 12, 3))
+	is.Equal([]TimeWindow[int]{
+		{Start: at(-5), End: at(5), Count: 1, Value: 1},
+		{Start: at(0), End: at(10), Count: 2, Value: 3},
+	}, closed)
+
+	is.Equal([]TimeWindow[int]{
+		{Start: at(5), End: at(15), Count: 2, Value: 5},
+		{Start: at(10), End: at(20), Count: 1, Value: 3},
+	}, w.Flush())
+
+	// gaps between windows
+	w = NewHoppingWindows(5*time.Second, 10*time.Second, sumTimedEvents, 0, TimeWindowOptions[timedEvent]{
+		Timestamp: timedEventTimestamp,
+	})
+
+	// the event of second 7 is ignored, but moves the watermark
+	is.Equal([]TimeWindow[int]{
+		{Start: at(0), End: at(5), Count: 2, Value: 4},
+	}, addTimedEvents(w, newTimedEvents(origin, 1, 1, 3, 3, 7, 2)))
+	is.Zero(w.Dropped())
+	is.Empty(w.Flush())
+
+	is.PanicsWithValue("lo.NewHoppingWindows: size and hop must be greater than 0", func() {
+		NewHoppingWindows(time.Second, 0, sumTimedEvents, 0, TimeWindowOptions[timedEvent]{})
+	})
+}
+
+func TestSessionWindows(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	origin := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(seconds int) time.Time {
+		return origin.Add(time.Duration(seconds) * time.Second)
+	}
+
+	w := NewSessionWindows(5*time.Second, func(agg []int, e timedEvent) []int {
+		return append(agg, e.value)
+	}, nil, TimeWindowOptions[timedEvent]{
+		Timestamp:       timedEventTimestamp,
+		AllowedLateness: 10 * time.Second,
+	})
+
+	// 0 and 10 open two sessions, that 4 and 8 merge; 15 and 20 are separated by exactly the gap
+	is.Nil(addTimedEvents(w, newTimedEvents(origin, 0, 1, 10, 2, 4, 3, 8, 4, 15, 5, 20, 6)))
+
+	is.Equal([]TimeWindow[[]int]{
+		{Start: at(0), End: at(15), Count: 4, Value: []int{1, 3, 4, 2}},
+		{Start: at(15), End: at(20), Count: 1, Value: []int{5}},
+	}, w.Add(timedEvent{at: at(30), value: 7}))
+
+	is.Equal([]TimeWindow[[]int]{
+		{Start: at(20), End: at(25), Count: 1, Value: []int{6}},
+	}, w.Add(timedEvent{at: at(40), value: 8}))
+
+	is.Empty(w.Add(timedEvent{at: at(18), value: 9}))
+	is.Equal(1, w.Dropped())
+
+	is.Equal([]TimeWindow[[]int]{
+		{Start: at(30), End: at(35), Count: 1, Value: []int{7}},
+		{Start: at(40), End: at(45), Count: 1, Value: []int{8}},
+	}, w.Flush())
+
+	is.PanicsWithValue("lo.NewSessionWindows: gap must be greater than 0", func() {
+		NewSessionWindows(-time.Second, sumTimedEvents, 0, TimeWindowOptions[timedEvent]{})
+	})
+}
+
+func TestTimeWindowsProcessingTime(t *testing.T) {
+	t.Parallel()
+	is := assert.New(t)
+
+	clock := xtime.NewFakeClockAt(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	w := NewTumblingWindows(time.Minute, func(agg, item int) int {
+		return agg + item
+	}, 0, TimeWindowOptions[int]{})
+	w.now = clock.Now
+
+	is.Empty(w.Add(1))
+	clock.Sleep(30 * time.Second)
+	is.Empty(w.Add(2))
+	clock.Sleep(40 * time.Second)
+
+	is.Equal([]TimeWindow[int]{
+		{Start: clock.Now().Truncate(time.Minute).Add(-time.Minute), End: clock.Now().Truncate(time.Minute), Count: 2, Value: 3},
+	}, w.Add(3))
+}